@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	SumAggregate     = "sum"
+	AvgAggregate     = "avg"
+	MinAggregate     = "min"
+	MaxAggregate     = "max"
+	CountUpAggregate = "count-up"
+)
+
+// Aggregate combines the values of a metric across several services into
+// a single value.
+type Aggregate struct {
+	Kind string
+}
+
+func NewAggregate(kind string) (*Aggregate, error) {
+	switch kind {
+	case "":
+		return nil, nil
+	case SumAggregate, AvgAggregate, MinAggregate, MaxAggregate, CountUpAggregate:
+		return &Aggregate{
+			Kind: kind,
+		}, nil
+	default:
+		return nil, fmt.Errorf("Unknown aggregate: %s", kind)
+	}
+}
+
+// Apply combines the numeric values, the rest of the values is ignored.
+// Unless counting, nil is returned when there are no numeric values.
+func (a *Aggregate) Apply(values []interface{}) interface{} {
+	count, integral := 0, true
+	sum, min, max := 0.0, math.Inf(1), math.Inf(-1)
+
+	for _, v := range values {
+		var value float64
+		switch n := v.(type) {
+		case int64:
+			value = float64(n)
+		case float64:
+			value = n
+			integral = false
+		default:
+			continue
+		}
+
+		count++
+		sum += value
+		min = math.Min(min, value)
+		max = math.Max(max, value)
+	}
+
+	if a.Kind == CountUpAggregate {
+		return int64(count)
+	}
+	if count == 0 {
+		return nil
+	}
+
+	var result float64
+	switch a.Kind {
+	case SumAggregate:
+		result = sum
+	case AvgAggregate:
+		return sum / float64(count)
+	case MinAggregate:
+		result = min
+	case MaxAggregate:
+		result = max
+	}
+
+	if integral {
+		return int64(result)
+	}
+	return result
+}
+
+func (a *Aggregate) String() string {
+	return a.Kind
+}
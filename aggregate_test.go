@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregate_Apply(t *testing.T) {
+	ints := []interface{}{int64(3), nil, int64(1), "text", int64(8)}
+	floats := []interface{}{int64(3), 1.5, nil}
+
+	tests := []struct {
+		name   string
+		kind   string
+		values []interface{}
+		want   interface{}
+	}{
+		{name: "sum of integers", kind: SumAggregate, values: ints, want: int64(12)},
+		{name: "sum of floats", kind: SumAggregate, values: floats, want: 4.5},
+		{name: "avg", kind: AvgAggregate, values: ints, want: 4.0},
+		{name: "min", kind: MinAggregate, values: ints, want: int64(1)},
+		{name: "max of floats", kind: MaxAggregate, values: floats, want: 3.0},
+		{name: "count-up", kind: CountUpAggregate, values: ints, want: int64(3)},
+		{name: "sum of nothing", kind: SumAggregate, values: []interface{}{nil}, want: nil},
+		{name: "count-up of nothing", kind: CountUpAggregate, values: []interface{}{nil}, want: int64(0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewAggregate(tt.kind)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, a.Apply(tt.values))
+		})
+	}
+}
+
+func TestNewAggregate_Unknown(t *testing.T) {
+	_, err := NewAggregate("median")
+	assert.EqualError(t, err, "Unknown aggregate: median")
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	AlertInactive = "inactive"
+	AlertPending  = "pending"
+	AlertFiring   = "firing"
+	AlertResolved = "resolved"
+)
+
+var comparisons = map[string]func(v, threshold float64) bool{
+	">":  func(v, threshold float64) bool { return v > threshold },
+	">=": func(v, threshold float64) bool { return v >= threshold },
+	"<":  func(v, threshold float64) bool { return v < threshold },
+	"<=": func(v, threshold float64) bool { return v <= threshold },
+	"==": func(v, threshold float64) bool { return v == threshold },
+	"!=": func(v, threshold float64) bool { return v != threshold },
+}
+
+// Alert watches a metric of one or all services. It becomes pending as soon
+// as the value crosses the threshold and fires once that has lasted for the
+// configured duration.
+type Alert struct {
+	Name       string
+	Service    string
+	Metric     *Metric
+	Comparison string
+	Threshold  float64
+	For        time.Duration
+	widgets    map[string][]string
+	states     map[string]*alertState
+}
+
+type alertState struct {
+	state string
+	since time.Time
+	value float64
+}
+
+// AlertEvent is sent to the webhooks when an alert starts firing or gets
+// resolved.
+type AlertEvent struct {
+	Alert      string    `json:"alert"`
+	Service    string    `json:"service"`
+	Metric     string    `json:"metric"`
+	State      string    `json:"state"`
+	Value      float64   `json:"value"`
+	Comparison string    `json:"comparison"`
+	Threshold  float64   `json:"threshold"`
+	Since      time.Time `json:"since"`
+}
+
+type AlertUpdate struct {
+	Name    string   `json:"n"`
+	Service string   `json:"s"`
+	Value   float64  `json:"v"`
+	Since   int64    `json:"t"`
+	Widgets []string `json:"w"`
+}
+
+func (a *Alert) Sources(all []string) []string {
+	if a.Service == "" {
+		return all
+	}
+	return []string{a.Service}
+}
+
+// Evaluate moves the alert of the service to its next state and returns the
+// event to notify about, if any. A missing value keeps the current state.
+func (a *Alert) Evaluate(service string, v interface{}, now time.Time) *AlertEvent {
+	value, ok := numeric(v)
+	if !ok {
+		return nil
+	}
+
+	if a.states == nil {
+		a.states = map[string]*alertState{}
+	}
+	s, ok := a.states[service]
+	if !ok {
+		s = &alertState{state: AlertInactive}
+		a.states[service] = s
+	}
+	s.value = value
+
+	if !comparisons[a.Comparison](value, a.Threshold) {
+		switch s.state {
+		case AlertFiring:
+			s.state, s.since = AlertResolved, now
+			return a.event(service, s)
+		case AlertPending:
+			s.state = AlertInactive
+		}
+		return nil
+	}
+
+	if s.state == AlertInactive || s.state == AlertResolved {
+		s.state, s.since = AlertPending, now
+	}
+	if s.state == AlertPending && now.Sub(s.since) >= a.For {
+		s.state = AlertFiring
+		return a.event(service, s)
+	}
+	return nil
+}
+
+// State returns the current state of the alert of the service.
+func (a *Alert) State(service string) string {
+	if s, ok := a.states[service]; ok {
+		return s.state
+	}
+	return AlertInactive
+}
+
+// Firing lists the services the alert is firing for.
+func (a *Alert) Firing() []*AlertUpdate {
+	services := []string{}
+	for service, s := range a.states {
+		if s.state == AlertFiring {
+			services = append(services, service)
+		}
+	}
+	sort.Strings(services)
+
+	updates := []*AlertUpdate{}
+	for _, service := range services {
+		s := a.states[service]
+		widgets := a.widgets[service]
+		if widgets == nil {
+			widgets = []string{}
+		}
+		updates = append(updates, &AlertUpdate{
+			Name:    a.Name,
+			Service: service,
+			Value:   s.value,
+			Since:   s.since.Unix(),
+			Widgets: widgets,
+		})
+	}
+	return updates
+}
+
+func (a *Alert) event(service string, s *alertState) *AlertEvent {
+	return &AlertEvent{
+		Alert:      a.Name,
+		Service:    service,
+		Metric:     a.Metric.String(),
+		State:      s.state,
+		Value:      s.value,
+		Comparison: a.Comparison,
+		Threshold:  a.Threshold,
+		Since:      s.since,
+	}
+}
+
+func (e *AlertEvent) String() string {
+	return fmt.Sprintf("Alert %s of %s is %s: %s = %v", e.Alert, e.Service, e.State, e.Metric, e.Value)
+}
+
+// SetWidgets remembers the widgets that show the metric of the alert, so
+// that they can be highlighted while it is firing.
+func (a *Alert) SetWidgets(widgets []Widget, all []string) {
+	a.widgets = map[string][]string{}
+	for _, w := range widgets {
+		for _, m := range widgetMetrics(w) {
+			if m.String() != a.Metric.String() {
+				continue
+			}
+			for _, service := range w.Sources(all) {
+				a.widgets[service] = append(a.widgets[service], w.ID())
+			}
+			break
+		}
+	}
+}
+
+// widgetMetrics returns the metrics a widget shows, one per column of a
+// Table.
+func widgetMetrics(w Widget) []*Metric {
+	switch w := w.(type) {
+	case *Gauge:
+		return []*Metric{w.Metric}
+	case *LineChart:
+		return []*Metric{w.Metric}
+	case *Text:
+		return []*Metric{w.Metric}
+	case *BarChart:
+		return []*Metric{w.Metric}
+	case *Stat:
+		return []*Metric{w.Metric}
+	case *Table:
+		metrics := []*Metric{}
+		for _, c := range w.Columns {
+			metrics = append(metrics, c.Metric)
+		}
+		return metrics
+	}
+	return nil
+}
+
+func numeric(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return float64(n), true
+	case float64:
+		return n, true
+	}
+	return 0, false
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/antonholmquist/jason"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAlert_Evaluate(t *testing.T) {
+	a := &Alert{
+		Name:       "heap",
+		Metric:     NewSafeMetric("memstats.HeapAlloc"),
+		Comparison: ">",
+		Threshold:  100,
+		For:        time.Minute,
+	}
+
+	start := time.Unix(1000, 0)
+
+	tests := []struct {
+		name      string
+		value     interface{}
+		after     time.Duration
+		wantState string
+		wantEvent string
+	}{
+		{name: "below threshold", value: int64(50), after: 0, wantState: AlertInactive},
+		{name: "crosses threshold", value: int64(150), after: 10 * time.Second, wantState: AlertPending},
+		{name: "missing value", value: nil, after: 20 * time.Second, wantState: AlertPending},
+		{name: "not long enough", value: 120.5, after: 40 * time.Second, wantState: AlertPending},
+		{name: "fires", value: int64(200), after: 70 * time.Second, wantState: AlertFiring, wantEvent: AlertFiring},
+		{name: "keeps firing", value: int64(200), after: 80 * time.Second, wantState: AlertFiring},
+		{name: "resolves", value: int64(100), after: 90 * time.Second, wantState: AlertResolved, wantEvent: AlertResolved},
+		{name: "pending again", value: int64(101), after: 100 * time.Second, wantState: AlertPending},
+		{name: "back to normal", value: int64(99), after: 110 * time.Second, wantState: AlertInactive},
+	}
+	for _, tt := range tests {
+		e := a.Evaluate("service1", tt.value, start.Add(tt.after))
+		assert.Equal(t, tt.wantState, a.State("service1"), tt.name)
+		if tt.wantEvent == "" {
+			assert.Nil(t, e, tt.name)
+		} else if assert.NotNil(t, e, tt.name) {
+			assert.Equal(t, tt.wantEvent, e.State, tt.name)
+		}
+	}
+
+	assert.Equal(t, AlertInactive, a.State("service2"))
+}
+
+func TestAlert_Firing(t *testing.T) {
+	a := &Alert{
+		Name:       "heap",
+		Metric:     NewSafeMetric("memstats.HeapAlloc"),
+		Comparison: ">=",
+		Threshold:  100,
+	}
+	a.SetWidgets([]Widget{
+		&Gauge{cid: "c1", Metric: NewSafeMetric("memstats.HeapAlloc"), Service: "service2"},
+		&LineChart{cid: "c2", Metric: NewSafeMetric("memstats.HeapAlloc")},
+		&Text{cid: "c3", Metric: NewSafeMetric("memstats.HeapSys"), Service: "service2"},
+		&Table{cid: "c4", Columns: []*TableColumn{
+			{Metric: NewSafeMetric("memstats.HeapSys")},
+			{Metric: NewSafeMetric("memstats.HeapAlloc")},
+		}},
+		&BarChart{cid: "c5", Metric: NewSafeMetric("memstats.HeapAlloc"), Services: []string{"service2"}},
+		&Stat{cid: "c6", Metric: NewSafeMetric("memstats.HeapAlloc"), Service: "service2"},
+	}, []string{"service1", "service2"})
+
+	now := time.Unix(1000, 0)
+	e := a.Evaluate("service2", int64(100), now)
+	assert.Equal(t, &AlertEvent{
+		Alert:      "heap",
+		Service:    "service2",
+		Metric:     "memstats.HeapAlloc",
+		State:      AlertFiring,
+		Value:      100,
+		Comparison: ">=",
+		Threshold:  100,
+		Since:      now,
+	}, e)
+	a.Evaluate("service1", int64(10), now)
+
+	assert.Equal(t, []*AlertUpdate{
+		{Name: "heap", Service: "service2", Value: 100, Since: 1000, Widgets: []string{"c1", "c2", "c4", "c5", "c6"}},
+	}, a.Firing())
+}
+
+type recordingNotifier struct {
+	events []*AlertEvent
+}
+
+func (n *recordingNotifier) Notify(e *AlertEvent) {
+	n.events = append(n.events, e)
+}
+
+func TestCrawler_ExtractUpdates_Alerts(t *testing.T) {
+	o, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"HeapAlloc": 300}}`))
+	assert.NoError(t, err)
+
+	notifier := &recordingNotifier{}
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets:  &Widgets{},
+		alerts: []*Alert{
+			{
+				Name:       "heap",
+				Metric:     NewSafeMetric("memstats.HeapAlloc"),
+				Comparison: ">",
+				Threshold:  200,
+			},
+		},
+		notifier: notifier,
+	}
+
+	data, err := json.Marshal(crawler.ExtractUpdates(map[string]*Expvars{
+		"service1": {Object: o},
+	}))
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(data), `"a":[{"n":"heap","s":"service1","v":300,`)
+	assert.Len(t, notifier.events, 1)
+	assert.Equal(t, "service1", notifier.events[0].Service)
+}
+
+func TestWebhooks_Notify(t *testing.T) {
+	received := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := ioutil.ReadAll(r.Body)
+		received <- r.Header.Get("Content-Type") + " " + string(data)
+	}))
+	defer server.Close()
+
+	NewWebhooks([]string{server.URL}).Notify(&AlertEvent{
+		Alert:      "heap",
+		Service:    "service1",
+		Metric:     "memstats.HeapAlloc",
+		State:      AlertFiring,
+		Value:      300,
+		Comparison: ">",
+		Threshold:  200,
+		Since:      time.Unix(1000, 0).UTC(),
+	})
+
+	select {
+	case body := <-received:
+		assert.Equal(t, `application/json {"alert":"heap","service":"service1","metric":"memstats.HeapAlloc","state":"firing","value":300,"comparison":"\u003e","threshold":200,"since":"1970-01-01T00:16:40Z"}`, body)
+	case <-time.After(time.Second):
+		t.Fatal("Did not get notification in time")
+	}
+}
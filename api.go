@@ -0,0 +1,425 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// API serves the configuration and the recorded values of line charts as
+// JSON, for use in scripts.
+type API struct {
+	reloader *Reloader
+	history  *History
+	storage  *Storage
+	crawler  *Crawler
+}
+
+type apiService struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`
+	Format  string `json:"format"`
+	Timeout string `json:"timeout"`
+}
+
+type apiWidget struct {
+	ID        string   `json:"id"`
+	Dashboard string   `json:"dashboard"`
+	Type      string   `json:"type"`
+	Title     string   `json:"title"`
+	Metric    string   `json:"metric,omitempty"`
+	Metrics   []string `json:"metrics,omitempty"`
+	Services  []string `json:"services"`
+	Series    []string `json:"series,omitempty"`
+}
+
+type apiSeries struct {
+	Service string      `json:"service"`
+	Metric  string      `json:"metric"`
+	Step    int64       `json:"step,omitempty"`
+	Points  []LinePoint `json:"points"`
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func (a *API) Register(mux *http.ServeMux) {
+	mux.HandleFunc("/api/services", a.services)
+	mux.HandleFunc("/api/widgets", a.widgets)
+	mux.HandleFunc("/api/metrics", a.metrics)
+	mux.HandleFunc("/api/series", a.series)
+	mux.HandleFunc("/api/export/", a.export)
+	mux.HandleFunc("/api/vars", a.vars)
+	mux.HandleFunc("/api/scratch", a.scratch)
+}
+
+func (a *API) services(w http.ResponseWriter, r *http.Request) {
+	services := []*apiService{}
+	for _, s := range a.reloader.Config().Services {
+		u := s.URL
+		u.User = nil
+
+		services = append(services, &apiService{
+			Name:    s.Name,
+			URL:     u.String(),
+			Format:  s.Format,
+			Timeout: s.Timeout.String(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, services)
+}
+
+func (a *API) widgets(w http.ResponseWriter, r *http.Request) {
+	conf := a.reloader.Config()
+
+	all := map[string]Widget{}
+	for _, widget := range conf.Widgets.All() {
+		all[widget.ID()] = widget
+	}
+
+	widgets := []*apiWidget{}
+	for _, d := range conf.Dashboards {
+		for _, row := range d.Layout.Rows {
+			for _, col := range row.Cols {
+				aw := &apiWidget{
+					ID:        col.ID,
+					Dashboard: d.Name,
+					Type:      col.Type,
+					Title:     col.Title,
+					Services:  col.Sources,
+				}
+				switch widget := all[col.ID].(type) {
+				case *LineChart:
+					aw.Metric = MetricTitle(widget.Metric, widget.Transform)
+					aw.Series = widget.Series(serviceNames(conf))
+				case *Table:
+					for _, m := range widgetMetrics(widget) {
+						aw.Metrics = append(aw.Metrics, m.String())
+					}
+				default:
+					if ms := widgetMetrics(widget); len(ms) == 1 {
+						aw.Metric = ms[0].String()
+					}
+				}
+				widgets = append(widgets, aw)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, widgets)
+}
+
+// metrics lists the paths of the last crawled variables of the service.
+func (a *API) metrics(w http.ResponseWriter, r *http.Request) {
+	vars := a.crawled(w, r.URL.Query().Get("service"))
+	if vars == nil {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, vars.Paths())
+}
+
+// vars returns the last crawled variables of the service as they are.
+func (a *API) vars(w http.ResponseWriter, r *http.Request) {
+	vars := a.crawled(w, r.URL.Query().Get("service"))
+	if vars == nil {
+		return
+	}
+
+	data, err := vars.MarshalJSON()
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, &apiError{err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(data)
+}
+
+// crawled returns the variables of the service from the last crawl, so that
+// the API never fetches a service on its own, or writes the error and
+// returns nil.
+func (a *API) crawled(w http.ResponseWriter, name string) *Expvars {
+	known := false
+	for _, s := range a.reloader.Config().Services {
+		known = known || s.Name == name
+	}
+	if !known {
+		writeJSON(w, http.StatusNotFound, &apiError{fmt.Sprintf("Unknown service: %s", name)})
+		return nil
+	}
+
+	res := a.crawler.Crawled(name)
+	if res == nil {
+		writeJSON(w, http.StatusServiceUnavailable, &apiError{fmt.Sprintf("Not crawled %s yet", name)})
+		return nil
+	}
+	if res.err != nil {
+		writeJSON(w, http.StatusBadGateway, &apiError{fmt.Sprintf("Could not crawl %s: %s", name, res.err)})
+		return nil
+	}
+	return res.vars
+}
+
+type apiPin struct {
+	Type    string `json:"type"`
+	Service string `json:"service"`
+	Metric  string `json:"metric"`
+}
+
+// scratch pins a variable of a service to the scratch dashboard as a line
+// chart or a text, or, on DELETE, removes all pinned widgets.
+func (a *API) scratch(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		// a form can't post JSON across sites, a page has to ask first
+		if t, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || t != "application/json" {
+			writeJSON(w, http.StatusUnsupportedMediaType, &apiError{fmt.Sprintf("Unsupported content type: %s", r.Header.Get("Content-Type"))})
+			return
+		}
+		var pin apiPin
+		if err := json.NewDecoder(r.Body).Decode(&pin); err != nil {
+			writeJSON(w, http.StatusBadRequest, &apiError{err.Error()})
+			return
+		}
+
+		item, err := pinItem(pin)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, &apiError{err.Error()})
+			return
+		}
+		known := false
+		for _, name := range serviceNames(a.reloader.Config()) {
+			known = known || name == pin.Service
+		}
+		if !known {
+			writeJSON(w, http.StatusBadRequest, &apiError{fmt.Sprintf("Unknown service: %s", pin.Service)})
+			return
+		}
+		if err := a.reloader.Pin(item); err != nil {
+			writeJSON(w, http.StatusBadRequest, &apiError{err.Error()})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]string{"dashboard": "/d/" + ScratchDashboard})
+	case http.MethodDelete:
+		if err := a.reloader.ClearScratch(); err != nil {
+			writeJSON(w, http.StatusInternalServerError, &apiError{err.Error()})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, &apiError{fmt.Sprintf("Method not allowed: %s", r.Method)})
+	}
+}
+
+// pinItem configures the widget that shows the pinned variable.
+func pinItem(pin apiPin) (RawItem, error) {
+	if pin.Service == "" || pin.Metric == "" {
+		return RawItem{}, errors.New("Both service and metric are required")
+	}
+
+	metric, ok := metricName(pin.Metric)
+	if !ok {
+		return RawItem{}, fmt.Errorf("Can't pin %s, its path has quotes", pin.Metric)
+	}
+
+	title := fmt.Sprintf("%s of %s", pin.Metric, pin.Service)
+	switch pin.Type {
+	case LineChartType:
+		return newItem(LineChartType, title, initLineChartSize, map[string]interface{}{
+			"metric":   metric,
+			"services": []string{pin.Service},
+		}), nil
+	case TextType:
+		return newItem(TextType, title, initTextSize, map[string]interface{}{
+			"metric":  metric,
+			"service": pin.Service,
+		}), nil
+	default:
+		return RawItem{}, fmt.Errorf("Can't pin as %s", pin.Type)
+	}
+}
+
+// series returns the recorded values of a metric of a service, which has to
+// be shown by a line chart. The metric can also be the name of an aggregate.
+func (a *API) series(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	service, metric := q.Get("service"), q.Get("metric")
+	if service == "" || metric == "" {
+		writeJSON(w, http.StatusBadRequest, &apiError{"Both service and metric are required"})
+		return
+	}
+
+	to, err := parseTime(q.Get("to"), Now())
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &apiError{err.Error()})
+		return
+	}
+	from, err := parseTime(q.Get("from"), to.Add(-time.Hour))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, &apiError{err.Error()})
+		return
+	}
+
+	var step time.Duration
+	if raw := q.Get("step"); raw != "" {
+		step, err = time.ParseDuration(raw)
+		if err != nil || step < time.Second {
+			writeJSON(w, http.StatusBadRequest, &apiError{fmt.Sprintf("Invalid step: %s", raw)})
+			return
+		}
+	}
+
+	conf := a.reloader.Config()
+	ch, index := findSeries(conf, service, metric)
+	if ch == nil {
+		writeJSON(w, http.StatusNotFound, &apiError{fmt.Sprintf("No line chart shows %s of %s", metric, service)})
+		return
+	}
+
+	points, err := a.points(ch, ch.Key(serviceNames(conf)), index, service, from, to)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, &apiError{err.Error()})
+		return
+	}
+	if step > 0 {
+		points = Downsample(points, step)
+	}
+
+	writeJSON(w, http.StatusOK, &apiSeries{
+		Service: service,
+		Metric:  metric,
+		Step:    int64(step / time.Second),
+		Points:  points,
+	})
+}
+
+// points reads the values of the series from the storage if there is one,
+// or from the in-memory history. The series is found by its index, or by its
+// label when the records name the series.
+func (a *API) points(ch *LineChart, key string, index int, label string, from, to time.Time) ([]LinePoint, error) {
+	points := []LinePoint{}
+
+	if a.storage != nil {
+		records, err := a.storage.Load(from)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range records {
+			i := index
+			if labels, ok := r.Labels[key]; ok {
+				i = -1
+				for j, l := range labels {
+					if l == label {
+						i = j
+					}
+				}
+			}
+			if r.Time > to.Unix() || i < 0 || i >= len(r.Series[key]) {
+				continue
+			}
+			points = append(points, LinePoint{Time: r.Time, Y: r.Series[key][i]})
+		}
+		return points, nil
+	}
+
+	series := a.history.Series(ch.ID())
+	if index >= len(series) {
+		return points, nil
+	}
+	for _, p := range series[index] {
+		if p.Time >= from.Unix() && p.Time <= to.Unix() {
+			points = append(points, p)
+		}
+	}
+	return points, nil
+}
+
+// findSeries finds a line chart that shows the metric of the service, and
+// the index of the service among the series of the chart.
+func findSeries(conf *Config, service, metric string) (*LineChart, int) {
+	names := serviceNames(conf)
+	for _, ch := range conf.Widgets.LineCharts {
+		if MetricTitle(ch.Metric, ch.Transform) != metric {
+			continue
+		}
+		for i, s := range ch.Series(names) {
+			if s == service {
+				return ch, i
+			}
+		}
+	}
+	return nil, 0
+}
+
+func serviceNames(conf *Config) []string {
+	names := []string{}
+	for _, s := range conf.Services {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// Downsample averages the points within each step. A step without values
+// becomes a missing value.
+func Downsample(points []LinePoint, step time.Duration) []LinePoint {
+	size := int64(step / time.Second)
+
+	result := []LinePoint{}
+	sum, count := 0.0, 0
+	for i, p := range points {
+		if p.Y != nil {
+			sum += *p.Y
+			count++
+		}
+
+		bucket := p.Time - p.Time%size
+		if i+1 < len(points) && points[i+1].Time-points[i+1].Time%size == bucket {
+			continue
+		}
+
+		point := LinePoint{Time: bucket}
+		if count > 0 {
+			avg := sum / float64(count)
+			point.Y = &avg
+		}
+		result = append(result, point)
+		sum, count = 0.0, 0
+	}
+	return result
+}
+
+// parseTime reads a unix timestamp or an RFC 3339 time.
+func parseTime(s string, def time.Time) (time.Time, error) {
+	if s == "" {
+		return def, nil
+	}
+	if sec, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(sec, 0), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, fmt.Errorf("Invalid time: %s", s)
+	}
+	return t, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Println("Error serializing response:", err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
@@ -1,9 +1,20 @@
 package main
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 )
 
 func LoadConf(path string) (*Config, error) {
@@ -15,7 +26,47 @@ func LoadConf(path string) (*Config, error) {
 	return raw.ParseConf()
 }
 
+// ReadConf reads the configuration file, or all configuration files (*.json)
+// of the directory. The services and dashboards of the files in a directory
+// are merged, and the rows of a file are served as a dashboard named after
+// the file.
 func ReadConf(path string) (*RawConfig, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return readConfFile(path)
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	conf := &RawConfig{}
+	for _, file := range files {
+		c, err := readConfFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s", file, err)
+		}
+
+		conf.Services = append(conf.Services, c.Services...)
+		if len(c.Rows) > 0 {
+			conf.Dashboards = append(conf.Dashboards, RawDashboard{
+				Name: strings.TrimSuffix(filepath.Base(file), ".json"),
+				Rows: c.Rows,
+			})
+		}
+		conf.Dashboards = append(conf.Dashboards, c.Dashboards...)
+		conf.Alerts = append(conf.Alerts, c.Alerts...)
+		conf.Webhooks = append(conf.Webhooks, c.Webhooks...)
+	}
+
+	return conf, nil
+}
+
+func readConfFile(path string) (*RawConfig, error) {
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
 		return nil, err
@@ -31,13 +82,51 @@ func ReadConf(path string) (*RawConfig, error) {
 }
 
 type RawConfig struct {
-	Services []RawService `json:"services"`
-	Rows     []RawRow     `json:"rows"`
+	Services   []RawService   `json:"services"`
+	Rows       []RawRow       `json:"rows,omitempty"`
+	Dashboards []RawDashboard `json:"dashboards,omitempty"`
+	Alerts     []RawAlert     `json:"alerts,omitempty"`
+	Webhooks   []string       `json:"webhooks,omitempty"`
+}
+
+type RawDashboard struct {
+	Name  string   `json:"name"`
+	Title string   `json:"title"`
+	Rows  []RawRow `json:"rows,omitempty"`
 }
 
 type RawService struct {
-	Name string `json:"name"`
-	URL  string `json:"url"`
+	Name            string            `json:"name"`
+	URL             string            `json:"url"`
+	Format          string            `json:"format,omitempty"`
+	Timeout         string            `json:"timeout,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+	BasicAuth       *RawBasicAuth     `json:"basic_auth,omitempty"`
+	BearerToken     string            `json:"bearer_token,omitempty"`
+	BearerTokenFile string            `json:"bearer_token_file,omitempty"`
+	BearerTokenEnv  string            `json:"bearer_token_env,omitempty"`
+	TLS             *RawTLS           `json:"tls,omitempty"`
+}
+
+type RawAlert struct {
+	Name       string  `json:"name"`
+	Service    string  `json:"service"`
+	Metric     string  `json:"metric"`
+	Comparison string  `json:"comparison"`
+	Threshold  float64 `json:"threshold"`
+	For        string  `json:"for"`
+}
+
+type RawBasicAuth struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type RawTLS struct {
+	CAFile             string `json:"ca_file"`
+	CertFile           string `json:"cert_file"`
+	KeyFile            string `json:"key_file"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify"`
 }
 
 type RawRow struct {
@@ -46,15 +135,56 @@ type RawRow struct {
 
 type RawItem struct {
 	Type  string           `json:"type"`
-	Title string           `json:"title"`
+	Title string           `json:"title,omitempty"`
 	Size  int              `json:"size"`
 	Conf  *json.RawMessage `json:"conf"`
 }
 
+// DefaultDashboard is the name of the dashboard defined by the top-level
+// rows of the configuration.
+const DefaultDashboard = "default"
+
+var dashboardName = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 type Config struct {
-	Services []*Service
-	Layout   *Layout
-	Widgets  *Widgets
+	Services   []*Service
+	Dashboards []*Dashboard
+	Widgets    *Widgets
+	Alerts     []*Alert
+	Notifier   Notifier
+}
+
+type Dashboard struct {
+	Name   string
+	Title  string
+	Layout *Layout
+	ids    map[string]struct{}
+}
+
+// Has tells whether the widget is shown on the dashboard.
+func (d *Dashboard) Has(id string) bool {
+	_, ok := d.ids[id]
+	return ok
+}
+
+// Sources maps the widgets of the dashboard to the services they read.
+func (d *Dashboard) Sources() map[string][]string {
+	sources := map[string][]string{}
+	for _, row := range d.Layout.Rows {
+		for _, col := range row.Cols {
+			sources[col.ID] = col.Sources
+		}
+	}
+	return sources
+}
+
+func (c *Config) Dashboard(name string) *Dashboard {
+	for _, d := range c.Dashboards {
+		if d.Name == name {
+			return d
+		}
+	}
+	return nil
 }
 
 type Layout struct {
@@ -66,20 +196,39 @@ type Row struct {
 }
 
 type Col struct {
-	ID     string
-	Title  string
-	Size   int
-	Legend bool
-	Series []string
+	ID      string
+	Type    string
+	Title   string
+	Size    int
+	Legend  bool
+	Series  []string
+	Sources []string
 }
 
 func (c *RawConfig) ParseConf() (*Config, error) {
+	if err := c.checkReserved(); err != nil {
+		return nil, err
+	}
+
+	return c.parseConf()
+}
+
+// checkReserved rejects the dashboards that would clash with the scratch
+// dashboard, which only holds the widgets pinned from the explore page.
+func (c *RawConfig) checkReserved() error {
+	for _, raw := range c.Dashboards {
+		if raw.Name == ScratchDashboard {
+			return fmt.Errorf("Reserved dashboard name: %s holds the pinned widgets", raw.Name)
+		}
+	}
+	return nil
+}
+
+func (c *RawConfig) parseConf() (*Config, error) {
 	config := &Config{
-		Services: []*Service{},
-		Layout: &Layout{
-			Rows: []*Row{},
-		},
-		Widgets: &Widgets{},
+		Services:   []*Service{},
+		Dashboards: []*Dashboard{},
+		Widgets:    &Widgets{},
 	}
 
 	defaultSeries := []string{}
@@ -89,11 +238,122 @@ func (c *RawConfig) ParseConf() (*Config, error) {
 		if err != nil {
 			return nil, err
 		}
+		for _, other := range config.Services {
+			if other.Name == s.Name {
+				return nil, fmt.Errorf("Duplicate service: %s", s.Name)
+			}
+		}
 		config.Services = append(config.Services, s)
 		defaultSeries = append(defaultSeries, s.Name)
 	}
 
-	for _, row := range c.Rows {
+	dashboards := c.Dashboards
+	if len(c.Rows) > 0 {
+		dashboards = append([]RawDashboard{{Name: DefaultDashboard, Rows: c.Rows}}, dashboards...)
+	}
+
+	for _, raw := range dashboards {
+		if !dashboardName.MatchString(raw.Name) {
+			return nil, fmt.Errorf("Invalid dashboard name: %q", raw.Name)
+		}
+		if config.Dashboard(raw.Name) != nil {
+			return nil, fmt.Errorf("Duplicate dashboard: %s", raw.Name)
+		}
+
+		d, err := ReadDashboard(raw, config.Widgets, defaultSeries)
+		if err != nil {
+			return nil, err
+		}
+		config.Dashboards = append(config.Dashboards, d)
+	}
+
+	for _, raw := range c.Alerts {
+		a, err := ReadAlert(raw, config.Services)
+		if err != nil {
+			return nil, err
+		}
+		for _, other := range config.Alerts {
+			if other.Name == a.Name {
+				return nil, fmt.Errorf("Duplicate alert: %s", a.Name)
+			}
+		}
+		a.SetWidgets(config.Widgets.All(), defaultSeries)
+		config.Alerts = append(config.Alerts, a)
+	}
+
+	for _, raw := range c.Webhooks {
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("Invalid webhook: %s", raw)
+		}
+	}
+	if len(c.Webhooks) > 0 {
+		config.Notifier = NewWebhooks(c.Webhooks)
+	}
+
+	return config, nil
+}
+
+func ReadAlert(raw RawAlert, services []*Service) (*Alert, error) {
+	if raw.Name == "" {
+		return nil, errors.New("Alert without name")
+	}
+
+	if raw.Service != "" {
+		found := false
+		for _, s := range services {
+			found = found || s.Name == raw.Service
+		}
+		if !found {
+			return nil, fmt.Errorf("Unknown service of alert %s: %s", raw.Name, raw.Service)
+		}
+	}
+
+	if _, ok := comparisons[raw.Comparison]; !ok {
+		return nil, fmt.Errorf("Unknown comparison of alert %s: %s", raw.Name, raw.Comparison)
+	}
+
+	var d time.Duration
+	if raw.For != "" {
+		var err error
+		d, err = time.ParseDuration(raw.For)
+		if err != nil || d < 0 {
+			return nil, fmt.Errorf("Invalid duration of alert %s: %s", raw.Name, raw.For)
+		}
+	}
+
+	m, err := NewMetric(raw.Metric)
+	if err != nil {
+		return nil, err
+	}
+	if m.Wildcard() {
+		return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", raw.Metric)
+	}
+
+	return &Alert{
+		Name:       raw.Name,
+		Service:    raw.Service,
+		Metric:     m,
+		Comparison: raw.Comparison,
+		Threshold:  raw.Threshold,
+		For:        d,
+	}, nil
+}
+
+func ReadDashboard(raw RawDashboard, widgets *Widgets, defaultSeries []string) (*Dashboard, error) {
+	dashboard := &Dashboard{
+		Name:  raw.Name,
+		Title: raw.Title,
+		Layout: &Layout{
+			Rows: []*Row{},
+		},
+		ids: map[string]struct{}{},
+	}
+	if dashboard.Title == "" {
+		dashboard.Title = raw.Name
+	}
+
+	for _, row := range raw.Rows {
 		cols := []*Col{}
 
 		for _, item := range row.Items {
@@ -102,12 +362,13 @@ func (c *RawConfig) ParseConf() (*Config, error) {
 				return nil, err
 			}
 
-			c.SetID(config.Widgets.NextID())
+			c.SetID(widgets.NextID())
 
-			err = config.Widgets.Append(c)
+			err = widgets.Append(c)
 			if err != nil {
 				return nil, err
 			}
+			dashboard.ids[c.ID()] = struct{}{}
 
 			title := item.Title
 			if len(item.Title) == 0 {
@@ -116,41 +377,170 @@ func (c *RawConfig) ParseConf() (*Config, error) {
 
 			var series []string
 			if c.HasLegend() {
-				series = c.Series()
-				if len(series) == 0 {
-					series = defaultSeries
-				}
+				series = c.Series(defaultSeries)
 			}
 
 			cols = append(cols, &Col{
-				ID:     c.ID(),
-				Title:  title,
-				Size:   item.Size,
-				Legend: c.HasLegend(),
-				Series: series,
+				ID:      c.ID(),
+				Type:    item.Type,
+				Title:   title,
+				Size:    item.Size,
+				Legend:  c.HasLegend(),
+				Series:  series,
+				Sources: c.Sources(defaultSeries),
 			})
 		}
 
-		config.Layout.Rows = append(config.Layout.Rows, &Row{
+		dashboard.Layout.Rows = append(dashboard.Layout.Rows, &Row{
 			Cols: cols,
 		})
 	}
 
-	return config, nil
+	return dashboard, nil
 }
 
 func ReadService(raw RawService) (*Service, error) {
-	url, err := ParseURL(raw.URL)
+	var path string
+	var parse func(r io.Reader) (*Expvars, error)
+
+	switch raw.Format {
+	case "":
+		raw.Format = ExpvarFormat
+		fallthrough
+	case ExpvarFormat:
+		path, parse = "/debug/vars", ParseExpvars
+	case PrometheusFormat:
+		path, parse = "/metrics", ParsePrometheus
+	default:
+		return nil, fmt.Errorf("Unknown format of service %s: %s", raw.Name, raw.Format)
+	}
+
+	url, err := parseURL(raw.URL, path)
 	if err != nil {
 		return nil, err
 	}
 
+	timeout := time.Second
+	if raw.Timeout != "" {
+		timeout, err = time.ParseDuration(raw.Timeout)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("Invalid timeout of service %s: %s", raw.Name, raw.Timeout)
+		}
+	}
+
+	f, err := ReadFetcher(raw)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid configuration of service %s: %s", raw.Name, err)
+	}
+	f.client.Timeout = timeout
+	f.parse = parse
+
 	return &Service{
-		Name: raw.Name,
-		URL:  *url,
+		Name:    raw.Name,
+		URL:     *url,
+		Format:  raw.Format,
+		Timeout: timeout,
+		Fetcher: f,
 	}, nil
 }
 
+// ReadFetcher prepares a fetcher that sends the headers, credentials and
+// client certificates the service requires.
+func ReadFetcher(raw RawService) (*fetcher, error) {
+	f := &fetcher{
+		client: &http.Client{},
+		header: http.Header{},
+	}
+
+	for name, value := range raw.Headers {
+		f.header.Set(name, value)
+	}
+
+	tokens := 0
+	for _, t := range []string{raw.BearerToken, raw.BearerTokenFile, raw.BearerTokenEnv} {
+		if t != "" {
+			tokens++
+		}
+	}
+	if tokens > 1 {
+		return nil, errors.New("only one of bearer_token, bearer_token_file and bearer_token_env can be set")
+	}
+	if tokens > 0 && raw.BasicAuth != nil {
+		return nil, errors.New("basic_auth and bearer token can't be used together")
+	}
+
+	switch {
+	case raw.BasicAuth != nil:
+		f.auth = BasicAuth(raw.BasicAuth.Username, raw.BasicAuth.Password)
+	case raw.BearerToken != "":
+		f.auth = BearerAuth(func() (string, error) {
+			return raw.BearerToken, nil
+		})
+	case raw.BearerTokenFile != "":
+		f.auth = BearerAuth(func() (string, error) {
+			data, err := ioutil.ReadFile(raw.BearerTokenFile)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimSpace(string(data)), nil
+		})
+	case raw.BearerTokenEnv != "":
+		token, ok := os.LookupEnv(raw.BearerTokenEnv)
+		if !ok {
+			return nil, fmt.Errorf("environment variable %s is not set", raw.BearerTokenEnv)
+		}
+		f.auth = BearerAuth(func() (string, error) {
+			return token, nil
+		})
+	}
+
+	if raw.TLS != nil {
+		config, err := ReadTLSConfig(raw.TLS)
+		if err != nil {
+			return nil, err
+		}
+		f.client.Transport = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: config,
+		}
+	}
+
+	return f, nil
+}
+
+func ReadTLSConfig(raw *RawTLS) (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: raw.InsecureSkipVerify,
+	}
+
+	if raw.CAFile != "" {
+		data, err := ioutil.ReadFile(raw.CAFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s", raw.CAFile)
+		}
+		config.RootCAs = pool
+	}
+
+	if raw.CertFile != "" || raw.KeyFile != "" {
+		if raw.CertFile == "" || raw.KeyFile == "" {
+			return nil, errors.New("both cert_file and key_file are required for a client certificate")
+		}
+
+		cert, err := tls.LoadX509KeyPair(raw.CertFile, raw.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	return config, nil
+}
+
 func ReadChart(item RawItem) (Widget, error) {
 	if item.Conf == nil {
 		return nil, fmt.Errorf("Missing configuration for: %s", item.Type)
@@ -163,6 +553,18 @@ func ReadChart(item RawItem) (Widget, error) {
 		return ReadLineChart(item.Conf)
 	case TextType:
 		return ReadText(item.Conf)
+	case StatusType:
+		return ReadStatus(item.Conf)
+	case GCPausesType:
+		return ReadGCPauses(item.Conf)
+	case TableType:
+		return ReadTable(item.Conf)
+	case BarChartType:
+		return ReadBarChart(item.Conf)
+	case HistogramType:
+		return ReadHistogram(item.Conf)
+	case StatType:
+		return ReadStat(item.Conf)
 	default:
 		return nil, fmt.Errorf("Unknown widget type: %s", item.Type)
 	}
@@ -181,6 +583,18 @@ func ReadLineChart(data *json.RawMessage) (*LineChart, error) {
 	}
 	widget.Metric = metric
 
+	transform, err := NewTransform(widget.TransformName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Transform = transform
+
+	aggregate, err := NewAggregate(widget.AggregateName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Aggregate = aggregate
+
 	return &widget, nil
 }
 
@@ -195,8 +609,23 @@ func ReadGauge(data *json.RawMessage) (*Gauge, error) {
 	if err != nil {
 		return nil, err
 	}
+	if metric.Wildcard() {
+		return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", widget.MetricName)
+	}
 	widget.Metric = metric
 
+	transform, err := NewTransform(widget.TransformName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Transform = transform
+
+	aggregate, err := NewAggregate(widget.AggregateName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Aggregate = aggregate
+
 	return &widget, nil
 }
 
@@ -211,7 +640,199 @@ func ReadText(data *json.RawMessage) (*Text, error) {
 	if err != nil {
 		return nil, err
 	}
+	if metric.Wildcard() {
+		return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", widget.MetricName)
+	}
+	widget.Metric = metric
+
+	transform, err := NewTransform(widget.TransformName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Transform = transform
+
+	aggregate, err := NewAggregate(widget.AggregateName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Aggregate = aggregate
+
+	return &widget, nil
+}
+
+func ReadStatus(data *json.RawMessage) (*Status, error) {
+	var widget Status
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	return &widget, nil
+}
+
+func ReadGCPauses(data *json.RawMessage) (*GCPauses, error) {
+	var widget GCPauses
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	if widget.MaxValue == 0 {
+		widget.MaxValue = 10
+	}
+	if widget.MaxValue < 0 {
+		return nil, fmt.Errorf("Invalid max of GC pauses: %v", widget.MaxValue)
+	}
+
+	if widget.Buckets == 0 {
+		widget.Buckets = 10
+	}
+	if widget.Buckets < 0 || widget.Buckets > 100 {
+		return nil, fmt.Errorf("Invalid number of buckets of GC pauses: %d", widget.Buckets)
+	}
+
+	return &widget, nil
+}
+
+func ReadTable(data *json.RawMessage) (*Table, error) {
+	var widget Table
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(widget.Columns) == 0 {
+		return nil, fmt.Errorf("Table has no columns")
+	}
+
+	for _, c := range widget.Columns {
+		metric, err := NewMetric(c.MetricName)
+		if err != nil {
+			return nil, err
+		}
+		if metric.Wildcard() {
+			return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", c.MetricName)
+		}
+		c.Metric = metric
+
+		transform, err := NewTransform(c.TransformName)
+		if err != nil {
+			return nil, err
+		}
+		c.Transform = transform
+
+		if !isFormat(c.Format) {
+			return nil, fmt.Errorf("Unknown format of column %s: %s", c.MetricName, c.Format)
+		}
+
+		if c.Label == "" {
+			c.Label = MetricTitle(c.Metric, c.Transform)
+		}
+	}
+
+	return &widget, nil
+}
+
+func ReadBarChart(data *json.RawMessage) (*BarChart, error) {
+	var widget BarChart
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := NewMetric(widget.MetricName)
+	if err != nil {
+		return nil, err
+	}
+	if metric.Wildcard() {
+		return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", widget.MetricName)
+	}
 	widget.Metric = metric
 
+	transform, err := NewTransform(widget.TransformName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Transform = transform
+
+	switch widget.Sort {
+	case "", AscendingOrder, DescendingOrder:
+	default:
+		return nil, fmt.Errorf("Unknown sort order of bar chart: %s", widget.Sort)
+	}
+
+	return &widget, nil
+}
+
+func ReadHistogram(data *json.RawMessage) (*Histogram, error) {
+	var widget Histogram
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := NewMetric(widget.MetricName)
+	if err != nil {
+		return nil, err
+	}
+	if metric.expr != nil || metric.Wildcard() {
+		return nil, fmt.Errorf("Histograms need the path of a map or an array: %s", widget.MetricName)
+	}
+	widget.Metric = metric
+
+	if widget.Percentiles == nil {
+		widget.Percentiles = []float64{50, 90, 99}
+	}
+	for _, p := range widget.Percentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("Invalid percentile of histogram %s: %v", widget.MetricName, p)
+		}
+	}
+
+	if widget.Buckets == 0 {
+		widget.Buckets = 10
+	}
+	if widget.Buckets < 0 || widget.Buckets > 100 {
+		return nil, fmt.Errorf("Invalid number of buckets of histogram %s: %d", widget.MetricName, widget.Buckets)
+	}
+
+	return &widget, nil
+}
+
+func ReadStat(data *json.RawMessage) (*Stat, error) {
+	var widget Stat
+	err := json.Unmarshal(*data, &widget)
+	if err != nil {
+		return nil, err
+	}
+
+	metric, err := NewMetric(widget.MetricName)
+	if err != nil {
+		return nil, err
+	}
+	if metric.Wildcard() {
+		return nil, fmt.Errorf("Wildcards are only supported by line charts: %s", widget.MetricName)
+	}
+	widget.Metric = metric
+
+	transform, err := NewTransform(widget.TransformName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Transform = transform
+
+	aggregate, err := NewAggregate(widget.AggregateName)
+	if err != nil {
+		return nil, err
+	}
+	widget.Aggregate = aggregate
+
+	if !isFormat(widget.Format) {
+		return nil, fmt.Errorf("Unknown format of stat %s: %s", widget.MetricName, widget.Format)
+	}
+	if widget.Decimals != nil && (*widget.Decimals < 0 || *widget.Decimals > 10) {
+		return nil, fmt.Errorf("Invalid decimals of stat %s: %d", widget.MetricName, *widget.Decimals)
+	}
+
 	return &widget, nil
 }
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ParseTestConf(t *testing.T, data string) (*Config, error) {
+	var raw RawConfig
+	err := json.Unmarshal([]byte(data), &raw)
+	assert.NoError(t, err)
+
+	return raw.ParseConf()
+}
+
+func TestRawConfig_ParseConf_Dashboards(t *testing.T) {
+	conf, err := ParseTestConf(t, `{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [{"type": "Text", "size": 2, "conf": {"service": "service1", "metric": "a"}}]}],
+		"dashboards": [
+			{"name": "team-a", "title": "Team A", "rows": [{"items": [{"type": "LineChart", "size": 6, "conf": {"metric": "b"}}]}]},
+			{"name": "team-b", "rows": [{"items": [{"type": "Gauge", "size": 2, "conf": {"service": "service1", "metric": "c", "max": 10}}]}]}
+		]
+	}`)
+	assert.NoError(t, err)
+
+	assert.Len(t, conf.Dashboards, 3)
+	assert.Equal(t, DefaultDashboard, conf.Dashboards[0].Name)
+	assert.Equal(t, "Team A", conf.Dashboards[1].Title)
+	assert.Equal(t, "team-b", conf.Dashboards[2].Title)
+
+	assert.True(t, conf.Dashboards[0].Has("c1"))
+	assert.True(t, conf.Dashboards[1].Has("c2"))
+	assert.False(t, conf.Dashboards[1].Has("c1"))
+	assert.Equal(t, "c3", conf.Dashboard("team-b").Layout.Rows[0].Cols[0].ID)
+	assert.Nil(t, conf.Dashboard("team-c"))
+
+	assert.Len(t, conf.Widgets.All(), 3)
+}
+
+func TestRawConfig_ParseConf_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "duplicate service",
+			data:    `{"services": [{"name": "s1", "url": "localhost:1"}, {"name": "s1", "url": "localhost:2"}]}`,
+			wantErr: "Duplicate service: s1",
+		},
+		{
+			name:    "duplicate dashboard",
+			data:    `{"dashboards": [{"name": "a"}, {"name": "a"}]}`,
+			wantErr: "Duplicate dashboard: a",
+		},
+		{
+			name:    "duplicate alert",
+			data:    `{"alerts": [{"name": "a", "metric": "x", "comparison": ">"}, {"name": "a", "metric": "y", "comparison": ">"}]}`,
+			wantErr: "Duplicate alert: a",
+		},
+		{
+			name:    "unknown service of alert",
+			data:    `{"alerts": [{"name": "a", "service": "s1", "metric": "x", "comparison": ">"}]}`,
+			wantErr: "Unknown service of alert a: s1",
+		},
+		{
+			name:    "unknown comparison",
+			data:    `{"alerts": [{"name": "a", "metric": "x", "comparison": "=>"}]}`,
+			wantErr: "Unknown comparison of alert a: =>",
+		},
+		{
+			name:    "wildcard in gauge",
+			data:    `{"rows": [{"items": [{"type": "Gauge", "conf": {"metric": "handlers.*.count", "max": 10}}]}]}`,
+			wantErr: "Wildcards are only supported by line charts: handlers.*.count",
+		},
+		{
+			name:    "invalid buckets of GC pauses",
+			data:    `{"rows": [{"items": [{"type": "GCPauses", "conf": {"buckets": 1000}}]}]}`,
+			wantErr: "Invalid number of buckets of GC pauses: 1000",
+		},
+		{
+			name:    "unknown sort order of bar chart",
+			data:    `{"rows": [{"items": [{"type": "BarChart", "conf": {"metric": "goroutines", "sort": "up"}}]}]}`,
+			wantErr: "Unknown sort order of bar chart: up",
+		},
+		{
+			name:    "histogram of an expression",
+			data:    `{"rows": [{"items": [{"type": "Histogram", "conf": {"metric": "a + b"}}]}]}`,
+			wantErr: "Histograms need the path of a map or an array: a + b",
+		},
+		{
+			name:    "invalid percentile of histogram",
+			data:    `{"rows": [{"items": [{"type": "Histogram", "conf": {"metric": "latency", "percentiles": [50, 999]}}]}]}`,
+			wantErr: "Invalid percentile of histogram latency: 999",
+		},
+		{
+			name:    "unknown format of stat",
+			data:    `{"rows": [{"items": [{"type": "Stat", "conf": {"metric": "a", "service": "s", "format": "kb"}}]}]}`,
+			wantErr: "Unknown format of stat a: kb",
+		},
+		{
+			name:    "invalid decimals of stat",
+			data:    `{"rows": [{"items": [{"type": "Stat", "conf": {"metric": "a", "service": "s", "decimals": -1}}]}]}`,
+			wantErr: "Invalid decimals of stat a: -1",
+		},
+		{
+			name:    "table without columns",
+			data:    `{"rows": [{"items": [{"type": "Table", "conf": {"services": ["a"]}}]}]}`,
+			wantErr: "Table has no columns",
+		},
+		{
+			name:    "unknown format of table column",
+			data:    `{"rows": [{"items": [{"type": "Table", "conf": {"columns": [{"metric": "memstats.Alloc", "format": "kb"}]}}]}]}`,
+			wantErr: "Unknown format of column memstats.Alloc: kb",
+		},
+		{
+			name:    "invalid duration",
+			data:    `{"alerts": [{"name": "a", "metric": "x", "comparison": ">", "for": "soon"}]}`,
+			wantErr: "Invalid duration of alert a: soon",
+		},
+		{
+			name:    "invalid webhook",
+			data:    `{"webhooks": ["localhost:9000"]}`,
+			wantErr: "Invalid webhook: localhost:9000",
+		},
+		{
+			name:    "invalid dashboard name",
+			data:    `{"dashboards": [{"name": "team a"}]}`,
+			wantErr: `Invalid dashboard name: "team a"`,
+		},
+		{
+			name:    "reserved dashboard name",
+			data:    `{"dashboards": [{"name": "scratch"}]}`,
+			wantErr: "Reserved dashboard name: scratch holds the pinned widgets",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTestConf(t, tt.data)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadConf_Directory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dashboards")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	writeConf(t, filepath.Join(dir, "services.json"), `{"services": [{"name": "service1", "url": "localhost:4004"}]}`)
+	writeConf(t, filepath.Join(dir, "memory.json"), `{"rows": [{"items": [{"type": "LineChart", "conf": {"metric": "memstats.Alloc"}}]}]}`)
+	writeConf(t, filepath.Join(dir, "teams.json"), `{"dashboards": [{"name": "team-a", "rows": []}]}`)
+	writeConf(t, filepath.Join(dir, "notes.txt"), `not a configuration`)
+
+	conf, err := LoadConf(dir)
+	assert.NoError(t, err)
+
+	assert.Len(t, conf.Services, 1)
+	assert.Len(t, conf.Dashboards, 2)
+	assert.Equal(t, "memory", conf.Dashboards[0].Name)
+	assert.Equal(t, "team-a", conf.Dashboards[1].Name)
+}
@@ -3,96 +3,679 @@ package main
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
+
+	"github.com/antonholmquist/jason"
 )
 
+// LinePoint, GaugeUpdate and TextUpdate carry a nil value when the metric
+// is missing, so that the dashboard can tell it from a zero.
 type LinePoint struct {
-	Time int64 `json:"time"`
-	Y    int64 `json:"y"`
+	Time int64    `json:"time"`
+	Y    *float64 `json:"y"`
 }
 
+// LineChartUpdate names its series only when they change at crawl time, as
+// the series of wildcard metrics do.
 type LineChartUpdate struct {
 	ID     string      `json:"i"`
 	Points []LinePoint `json:"p"`
+	Series []string    `json:"s,omitempty"`
 }
 
 type GaugeUpdate struct {
-	ID    string  `json:"i"`
-	Value float64 `json:"v"`
+	ID    string   `json:"i"`
+	Value *float64 `json:"v"`
 }
 
 type TextUpdate struct {
-	ID    string `json:"i"`
-	Value string `json:"v"`
+	ID    string  `json:"i"`
+	Value *string `json:"v"`
+}
+
+// GCPause is a garbage collection pause of a service: when it ended and how
+// long it took, both in milliseconds.
+type GCPause struct {
+	Service  string  `json:"s"`
+	End      int64   `json:"e"`
+	Duration float64 `json:"d"`
+}
+
+// GCPausesUpdate carries the pauses since the last crawl along with the
+// range of the heatmap.
+type GCPausesUpdate struct {
+	ID      string     `json:"i"`
+	Time    int64      `json:"t"`
+	Pauses  []*GCPause `json:"p"`
+	Max     float64    `json:"m"`
+	Buckets int        `json:"b"`
+}
+
+// TableCell is a value of a Table as text, along with the number it was
+// formatted from, if any, and the threshold it reached.
+type TableCell struct {
+	Value *float64 `json:"v"`
+	Text  *string  `json:"t"`
+	Level string   `json:"l,omitempty"`
+}
+
+type TableRow struct {
+	Service string       `json:"s"`
+	Cells   []*TableCell `json:"c"`
+}
+
+type TableUpdate struct {
+	ID      string      `json:"i"`
+	Columns []string    `json:"h"`
+	Rows    []*TableRow `json:"r"`
+}
+
+type Bar struct {
+	Service string   `json:"s"`
+	Value   *float64 `json:"v"`
+}
+
+type BarChartUpdate struct {
+	ID         string `json:"i"`
+	Bars       []*Bar `json:"b"`
+	Horizontal bool   `json:"h,omitempty"`
+}
+
+// byValue orders the bars by their values, missing values last.
+type byValue struct {
+	bars       []*Bar
+	descending bool
+}
+
+func (b byValue) Len() int      { return len(b.bars) }
+func (b byValue) Swap(i, j int) { b.bars[i], b.bars[j] = b.bars[j], b.bars[i] }
+func (b byValue) Less(i, j int) bool {
+	x, y := b.bars[i].Value, b.bars[j].Value
+	if x == nil || y == nil {
+		return x != nil
+	}
+	if b.descending {
+		return *x > *y
+	}
+	return *x < *y
+}
+
+type HistogramBucket struct {
+	Bound string  `json:"u"`
+	Count float64 `json:"c"`
 }
 
+// HistogramUpdate carries the current distribution and a point of every
+// percentile. Snapshots add the retained points of the percentiles.
+type HistogramUpdate struct {
+	ID          string             `json:"i"`
+	Buckets     []*HistogramBucket `json:"b"`
+	Percentiles []LinePoint        `json:"p"`
+	History     [][]LinePoint      `json:"h,omitempty"`
+}
+
+// StatUpdate carries the value of a Stat as a point of its sparkline and as
+// formatted text, along with the threshold it reached. Snapshots add the
+// retained points of the sparkline.
+type StatUpdate struct {
+	ID      string      `json:"i"`
+	Point   LinePoint   `json:"p"`
+	Text    *string     `json:"t"`
+	Level   string      `json:"l,omitempty"`
+	History []LinePoint `json:"h,omitempty"`
+}
+
+// Formats of the numbers in a Table or a Stat.
+const (
+	NumberFormat   = "number"
+	BytesFormat    = "bytes"
+	PercentFormat  = "percent"
+	DurationFormat = "duration"
+	SIFormat       = "si"
+)
+
 type WidgetsUpdates struct {
-	Gauges     []*GaugeUpdate     `json:"g"`
-	LineCharts []*LineChartUpdate `json:"lc"`
-	Texts      []*TextUpdate      `json:"t"`
+	Gauges     []*GaugeUpdate      `json:"g"`
+	LineCharts []*LineChartUpdate  `json:"lc"`
+	Texts      []*TextUpdate       `json:"t"`
+	History    []*LineChartHistory `json:"h,omitempty"`
+	Alerts     []*AlertUpdate      `json:"a,omitempty"`
+	Statuses   []*StatusUpdate     `json:"st,omitempty"`
+	Health     []*ServiceHealth    `json:"sh,omitempty"`
+	GCPauses   []*GCPausesUpdate   `json:"gc,omitempty"`
+	Tables     []*TableUpdate      `json:"tb,omitempty"`
+	BarCharts  []*BarChartUpdate   `json:"bc,omitempty"`
+	Histograms []*HistogramUpdate  `json:"hg,omitempty"`
+	Stats      []*StatUpdate       `json:"sv,omitempty"`
+}
+
+// reloadMessage tells the clients to reload the page after the dashboard
+// configuration has changed.
+var reloadMessage = []byte(`{"reload":true}`)
+
+// Filter returns the updates of the widgets the function accepts. A nil
+// function accepts all widgets. The firing alerts and the health of the
+// services are kept as they are.
+func (u *WidgetsUpdates) Filter(has func(id string) bool) *WidgetsUpdates {
+	if has == nil {
+		return u
+	}
+
+	f := &WidgetsUpdates{
+		Gauges:     []*GaugeUpdate{},
+		LineCharts: []*LineChartUpdate{},
+		Texts:      []*TextUpdate{},
+		Alerts:     u.Alerts,
+		Health:     u.Health,
+	}
+	for _, g := range u.Gauges {
+		if has(g.ID) {
+			f.Gauges = append(f.Gauges, g)
+		}
+	}
+	for _, lc := range u.LineCharts {
+		if has(lc.ID) {
+			f.LineCharts = append(f.LineCharts, lc)
+		}
+	}
+	for _, t := range u.Texts {
+		if has(t.ID) {
+			f.Texts = append(f.Texts, t)
+		}
+	}
+	for _, st := range u.Statuses {
+		if has(st.ID) {
+			f.Statuses = append(f.Statuses, st)
+		}
+	}
+	for _, gc := range u.GCPauses {
+		if has(gc.ID) {
+			f.GCPauses = append(f.GCPauses, gc)
+		}
+	}
+	for _, tb := range u.Tables {
+		if has(tb.ID) {
+			f.Tables = append(f.Tables, tb)
+		}
+	}
+	for _, bc := range u.BarCharts {
+		if has(bc.ID) {
+			f.BarCharts = append(f.BarCharts, bc)
+		}
+	}
+	for _, hg := range u.Histograms {
+		if has(hg.ID) {
+			f.Histograms = append(f.Histograms, hg)
+		}
+	}
+	for _, sv := range u.Stats {
+		if has(sv.ID) {
+			f.Stats = append(f.Stats, sv)
+		}
+	}
+	if u.History != nil {
+		f.History = []*LineChartHistory{}
+		for _, h := range u.History {
+			if has(h.ID) {
+				f.History = append(f.History, h)
+			}
+		}
+	}
+	return f
 }
 
 type Crawler struct {
-	interval time.Duration
-	fetcher  Fetcher
-	hub      *Hub
-	services []*Service
-	widgets  *Widgets
-	done     chan struct{}
+	interval   time.Duration
+	fetcher    Fetcher
+	hub        *Hub
+	history    *History
+	services   []*Service
+	widgets    *Widgets
+	dashboards []*Dashboard
+	alerts     []*Alert
+	notifier   Notifier
+	health     map[string]*ServiceHealth
+	storage    *Storage
+	recorder   *Recorder
+	replayer   *Replayer
+	reloadCh   chan *reload
+	done       chan struct{}
+
+	// mu guards the last crawls of the services, read by the API
+	mu       sync.Mutex
+	crawled  map[string]*result
+	explored map[string]time.Time
 }
 
 type result struct {
 	service string
 	vars    *Expvars
+	err     error
+	latency time.Duration
 }
 
 func (c *Crawler) Start() {
 	for {
 		select {
-		case <-time.After(c.interval):
+		case <-time.After(c.tick()):
 			updates := c.ExtractUpdates(c.fetchAll())
-			data, err := json.Marshal(updates)
-			if err != nil {
-				fmt.Println("Error serializing response:", err)
-				continue
+			updates.Health = Health(c.health, c.names())
+			if c.history != nil {
+				c.history.Add(updates)
 			}
+			c.store(updates)
+
+			c.publish(updates)
+		case r := <-c.reloadCh:
+			c.apply(r)
 
-			c.hub.dataCh <- data
+			c.hub.dataCh <- &Message{Dashboard: r.dashboard, Data: reloadMessage}
 		case <-c.done:
 			return
 		}
 	}
 }
 
+// tick returns the wall time between two crawls. A replay is crawled at
+// the interval of the recording, so that a faster replay crawls more often.
+func (c *Crawler) tick() time.Duration {
+	if c.replayer != nil {
+		return c.replayer.Wall(c.interval)
+	}
+	return c.interval
+}
+
 func (c *Crawler) Stop() {
 	c.done <- struct{}{}
 }
 
+// publish sends every dashboard the updates of its widgets, or all updates
+// to everyone if there are no dashboards.
+func (c *Crawler) publish(updates *WidgetsUpdates) {
+	if len(c.dashboards) == 0 {
+		data, err := json.Marshal(updates)
+		if err != nil {
+			fmt.Println("Error serializing response:", err)
+			return
+		}
+
+		c.hub.dataCh <- &Message{Data: data}
+		return
+	}
+
+	for _, d := range c.dashboards {
+		data, err := json.Marshal(updates.Filter(d.Has))
+		if err != nil {
+			fmt.Println("Error serializing response:", err)
+			continue
+		}
+
+		c.hub.dataCh <- &Message{Dashboard: d.Name, Data: data}
+	}
+}
+
+// store appends the sampled values of the line charts to the storage.
+func (c *Crawler) store(updates *WidgetsUpdates) {
+	if c.storage == nil {
+		return
+	}
+
+	updated := map[string]*LineChartUpdate{}
+	for _, lc := range updates.LineCharts {
+		updated[lc.ID] = lc
+	}
+
+	names := c.names()
+	r := &Record{
+		Series: map[string][]*float64{},
+	}
+	for _, ch := range c.widgets.LineCharts {
+		key := ch.Key(names)
+		values := []*float64{}
+		if lc, ok := updated[ch.ID()]; ok {
+			for _, p := range lc.Points {
+				r.Time = p.Time
+				values = append(values, p.Y)
+			}
+			if lc.Series != nil {
+				if r.Labels == nil {
+					r.Labels = map[string][]string{}
+				}
+				r.Labels[key] = lc.Series
+			}
+		}
+		r.Series[key] = values
+	}
+	if r.Time == 0 {
+		return
+	}
+
+	if err := c.storage.Append(r); err != nil {
+		fmt.Println("Could not store crawled values:", err)
+	}
+}
+
+// Restore refills the history with the stored values of the line charts
+// sampled since the given time.
+func (c *Crawler) Restore(since time.Time) {
+	if c.storage == nil || c.history == nil {
+		return
+	}
+
+	records, err := c.storage.Load(since)
+	if err != nil {
+		fmt.Println("Could not load stored values:", err)
+		return
+	}
+
+	names := c.names()
+	for _, r := range records {
+		u := &WidgetsUpdates{
+			Gauges:     []*GaugeUpdate{},
+			LineCharts: []*LineChartUpdate{},
+			Texts:      []*TextUpdate{},
+		}
+		for _, ch := range c.widgets.LineCharts {
+			key := ch.Key(names)
+			values, ok := r.Series[key]
+			if !ok {
+				continue
+			}
+
+			lu := &LineChartUpdate{
+				ID:     ch.ID(),
+				Points: []LinePoint{},
+			}
+			if labels, ok := r.Labels[key]; ok {
+				// the series may have been found in a different order
+				lu.Series = ch.Observe(labels)
+				values = byLabel(values, labels, lu.Series)
+			}
+			for _, v := range values {
+				lu.Points = append(lu.Points, LinePoint{Time: r.Time, Y: v})
+			}
+			u.LineCharts = append(u.LineCharts, lu)
+		}
+		c.history.Add(u)
+	}
+}
+
+// byLabel orders the values of the labels as the series.
+func byLabel(values []*float64, labels []string, series []string) []*float64 {
+	found := map[string]*float64{}
+	for i, l := range labels {
+		if i < len(values) {
+			found[l] = values[i]
+		}
+	}
+
+	ordered := []*float64{}
+	for _, l := range series {
+		ordered = append(ordered, found[l])
+	}
+	return ordered
+}
+
+// reloadAlerts replaces the alerts, keeping the state of the ones that are
+// still configured, so that they don't fire or get resolved again.
+func (c *Crawler) reloadAlerts(alerts []*Alert) {
+	for _, a := range alerts {
+		for _, old := range c.alerts {
+			if old.Name == a.Name {
+				a.states = old.states
+			}
+		}
+	}
+	c.alerts = alerts
+}
+
+// reload is a configuration to switch to, and the dashboard that changed,
+// if it's the only one.
+type reload struct {
+	conf      *Config
+	dashboard string
+}
+
+// Reload replaces the services and widgets of the crawler. The change is
+// applied between two crawls and the clients are asked to reload the page.
+func (c *Crawler) Reload(conf *Config) {
+	c.reloadCh <- &reload{conf: conf}
+}
+
+// ReloadDashboard replaces the configuration after only the dashboard has
+// changed. Only the clients of the dashboard are asked to reload the page.
+func (c *Crawler) ReloadDashboard(conf *Config, name string) {
+	c.reloadCh <- &reload{conf: conf, dashboard: name}
+}
+
+// apply switches to the services and widgets of the configuration. The
+// history of the line charts is refilled from the storage, or else carried
+// over to the charts that are still configured. When only a dashboard has
+// changed, the other widgets are the same and so is their history.
+func (c *Crawler) apply(r *reload) {
+	if c.history != nil {
+		if r.dashboard != "" {
+			c.history.Rekey(widgetIDs(r.conf.Widgets))
+		} else if c.storage == nil {
+			c.history.Rekey(c.historyIDs(r.conf))
+		}
+	}
+
+	c.services = r.conf.Services
+	c.widgets = r.conf.Widgets
+	c.dashboards = r.conf.Dashboards
+	c.reloadAlerts(r.conf.Alerts)
+	c.notifier = r.conf.Notifier
+
+	if c.history != nil && r.dashboard == "" && c.storage != nil {
+		c.history.Reset()
+		c.Restore(Now().Add(-c.history.Retention()))
+	}
+}
+
+// widgetIDs maps the IDs of the widgets to themselves.
+func widgetIDs(widgets *Widgets) map[string]string {
+	ids := map[string]string{}
+	for _, w := range widgets.All() {
+		ids[w.ID()] = w.ID()
+	}
+	return ids
+}
+
+// historyIDs maps the current IDs of the line charts, stats and histograms
+// to their IDs in the configuration, matching them by their keys like stored
+// records are. Widgets with the same key are matched in order. The series of
+// wildcard metrics keep their order.
+func (c *Crawler) historyIDs(conf *Config) map[string]string {
+	ids := map[string]string{}
+	if c.widgets == nil {
+		return ids
+	}
+
+	names := c.names()
+	current := map[string][]string{}
+	charts := map[string]*LineChart{}
+	for _, ch := range c.widgets.LineCharts {
+		key := LineChartType + "|" + ch.Key(names)
+		current[key] = append(current[key], ch.ID())
+		charts[ch.ID()] = ch
+	}
+	for _, s := range c.widgets.Stats {
+		key := StatType + "|" + s.Key(names)
+		current[key] = append(current[key], s.ID())
+	}
+	for _, h := range c.widgets.Histograms {
+		key := HistogramType + "|" + h.Key(names)
+		current[key] = append(current[key], h.ID())
+	}
+
+	// match maps the first current widget with the key to the ID
+	match := func(key, id string) (string, bool) {
+		if len(current[key]) == 0 {
+			return "", false
+		}
+		old := current[key][0]
+		current[key] = current[key][1:]
+		ids[old] = id
+		return old, true
+	}
+
+	names = serviceNames(conf)
+	for _, ch := range conf.Widgets.LineCharts {
+		if old, ok := match(LineChartType+"|"+ch.Key(names), ch.ID()); ok && charts[old].Metric.Wildcard() {
+			ch.Observe(charts[old].Series(names))
+		}
+	}
+	for _, s := range conf.Widgets.Stats {
+		match(StatType+"|"+s.Key(names), s.ID())
+	}
+	for _, h := range conf.Widgets.Histograms {
+		match(HistogramType+"|"+h.Key(names), h.ID())
+	}
+	return ids
+}
+
+func (c *Crawler) names() []string {
+	names := []string{}
+	for _, service := range c.services {
+		names = append(names, service.Name)
+	}
+	return names
+}
+
+// record keeps track of the health of the service.
+func (c *Crawler) record(service string, err error, latency time.Duration) {
+	if c.health == nil {
+		c.health = map[string]*ServiceHealth{}
+	}
+
+	h, ok := c.health[service]
+	if !ok {
+		h = &ServiceHealth{Service: service}
+		c.health[service] = h
+	}
+	h.Record(err, latency, Now())
+}
+
+// exploreTimeout is how long a service that no widget shows keeps being
+// crawled after its variables were last asked for.
+const exploreTimeout = time.Minute
+
+// Crawled returns the last crawl of the service, or nil if it hasn't been
+// crawled yet. Services that no widget shows are crawled from the next
+// interval on, for as long as they are asked for.
+func (c *Crawler) Crawled(service string) *result {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.explored == nil {
+		c.explored = map[string]time.Time{}
+	}
+	c.explored[service] = time.Now()
+
+	return c.crawled[service]
+}
+
+// keep saves the result of crawling the service for Crawled.
+func (c *Crawler) keep(r *result) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.crawled == nil {
+		c.crawled = map[string]*result{}
+	}
+	c.crawled[r.service] = r
+}
+
+// explores tells whether the variables of the service were asked for lately.
+func (c *Crawler) explores(service string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	t, ok := c.explored[service]
+	return ok && time.Since(t) < exploreTimeout
+}
+
 func (c *Crawler) fetchAll() map[string]*Expvars {
 	vars := map[string]*Expvars{}
 
-	resCh := make(chan result, len(c.services))
+	names := c.names()
+	used := c.widgets.Sources(names)
+	for _, a := range c.alerts {
+		for _, s := range a.Sources(names) {
+			used[s] = struct{}{}
+		}
+	}
 
+	services := []*Service{}
 	for _, service := range c.services {
+		if _, ok := used[service.Name]; ok || c.explores(service.Name) {
+			services = append(services, service)
+		}
+	}
+
+	resCh := make(chan result, len(services))
+
+	for _, service := range services {
 		service := service
 		go func() {
-			vars, err := c.fetcher.Fetch(service.URL)
+			fetcher := c.fetcher
+			if service.Fetcher != nil {
+				fetcher = service.Fetcher
+			}
+			if c.replayer != nil {
+				fetcher = c.replayer.Fetcher(service.Name)
+			}
+
+			start := time.Now()
+			vars, err := fetcher.Fetch(service.URL)
 			if err != nil {
 				fmt.Printf("Failed to crawl '%s': %s\n", service.Name, err)
 			}
-			resCh <- result{service: service.Name, vars: vars}
+			if c.recorder != nil {
+				if err := c.recorder.Record(service.Name, vars, err, Now()); err != nil {
+					fmt.Println("Could not record crawl:", err)
+				}
+			}
+			resCh <- result{service: service.Name, vars: vars, err: err, latency: time.Since(start)}
 		}()
 	}
 
-	timeout := time.After(time.Second)
+	wait := time.Second
+	for _, service := range services {
+		if service.Timeout > wait {
+			wait = service.Timeout
+		}
+	}
+	timeout := time.After(wait)
+
+	pending := map[string]struct{}{}
+	for _, service := range services {
+		pending[service.Name] = struct{}{}
+	}
 
-	for i := 0; i < len(c.services); i++ {
+	for i := 0; i < len(services); i++ {
 		select {
 		case <-timeout:
 			fmt.Println("Timed out waiting for all crawling results")
+			for _, service := range services {
+				if _, ok := pending[service.Name]; ok {
+					err := fmt.Errorf("timed out after %s", wait)
+					c.record(service.Name, err, wait)
+					c.keep(&result{service: service.Name, err: err})
+				}
+			}
 			return vars
 		case r := <-resCh:
+			delete(pending, r.service)
+			c.record(r.service, r.err, r.latency)
+			c.keep(&r)
 			if r.vars != nil {
 				vars[r.service] = r.vars
 			}
@@ -109,103 +692,443 @@ func (c *Crawler) ExtractUpdates(vars map[string]*Expvars) *WidgetsUpdates {
 		Texts:      []*TextUpdate{},
 	}
 
-	now := Now().Unix()
+	now := Now()
 
 	for _, g := range c.widgets.Gauges {
+		v := c.readWidget(g.Metric, g.Transform, g.Aggregate, g.Service, g.Services, vars, now)
 		u.Gauges = append(u.Gauges, &GaugeUpdate{
 			ID:    g.ID(),
-			Value: GaugeValue(g.Metric, g.MaxValue, vars[g.Service]),
+			Value: GaugeValue(g.Metric, g.MaxValue, v),
 		})
 	}
 
 	for _, ch := range c.widgets.LineCharts {
+		if ch.Metric.Wildcard() {
+			u.LineCharts = append(u.LineCharts, c.readWildcard(ch, vars, now))
+			continue
+		}
+
 		lu := &LineChartUpdate{
 			ID:     ch.ID(),
 			Points: []LinePoint{},
 		}
-		if len(ch.Services) > 0 {
-			for _, s := range ch.Services {
+		values := c.readAll(ch.Metric, ch.Transform, ch.Services, vars, now)
+		if ch.Aggregate == nil || ch.ShowServices {
+			for _, v := range values {
 				lu.Points = append(lu.Points, LinePoint{
-					Time: now,
-					Y:    LineChartValue(ch.Metric, vars[s]),
-				})
-			}
-		} else {
-			for _, s := range c.services {
-				lu.Points = append(lu.Points, LinePoint{
-					Time: now,
-					Y:    LineChartValue(ch.Metric, vars[s.Name]),
+					Time: now.Unix(),
+					Y:    LineChartValue(ch.Metric, v),
 				})
 			}
 		}
+		if ch.Aggregate != nil {
+			lu.Points = append(lu.Points, LinePoint{
+				Time: now.Unix(),
+				Y:    LineChartValue(ch.Metric, ch.Aggregate.Apply(values)),
+			})
+		}
 		u.LineCharts = append(u.LineCharts, lu)
 	}
 
 	for _, t := range c.widgets.Texts {
+		v := c.readWidget(t.Metric, t.Transform, t.Aggregate, t.Service, t.Services, vars, now)
 		u.Texts = append(u.Texts, &TextUpdate{
 			ID:    t.ID(),
-			Value: TextValue(t.Metric, vars[t.Service]),
+			Value: TextValue(t.Metric, v),
+		})
+	}
+
+	for _, s := range c.widgets.Stats {
+		v := c.readWidget(s.Metric, s.Transform, s.Aggregate, s.Service, s.Services, vars, now)
+		su := StatValue(s, v)
+		su.ID = s.ID()
+		su.Point.Time = now.Unix()
+		u.Stats = append(u.Stats, su)
+	}
+
+	for _, st := range c.widgets.Statuses {
+		u.Statuses = append(u.Statuses, &StatusUpdate{
+			ID:       st.ID(),
+			Services: Health(c.health, st.Sources(c.names())),
 		})
 	}
 
+	for _, g := range c.widgets.GCPauses {
+		gu := &GCPausesUpdate{
+			ID:      g.ID(),
+			Time:    now.Unix(),
+			Pauses:  []*GCPause{},
+			Max:     g.MaxValue,
+			Buckets: g.Buckets,
+		}
+		for _, s := range g.Sources(c.names()) {
+			if vars[s] == nil {
+				continue
+			}
+			gu.Pauses = append(gu.Pauses, g.Pauses(s, vars[s])...)
+		}
+		u.GCPauses = append(u.GCPauses, gu)
+	}
+
+	for _, b := range c.widgets.BarCharts {
+		bu := &BarChartUpdate{
+			ID:         b.ID(),
+			Bars:       []*Bar{},
+			Horizontal: b.Horizontal,
+		}
+		for _, s := range b.Sources(c.names()) {
+			v := b.Transform.Apply(s, ReadMetric(b.Metric, vars[s]), now)
+			bu.Bars = append(bu.Bars, &Bar{
+				Service: s,
+				Value:   LineChartValue(b.Metric, v),
+			})
+		}
+		if b.Sort != "" {
+			sort.Stable(byValue{bars: bu.Bars, descending: b.Sort == DescendingOrder})
+		}
+		u.BarCharts = append(u.BarCharts, bu)
+	}
+
+	for _, h := range c.widgets.Histograms {
+		d := h.Distribution(h.Sources(c.names()), vars)
+		hu := &HistogramUpdate{
+			ID:          h.ID(),
+			Buckets:     []*HistogramBucket{},
+			Percentiles: []LinePoint{},
+		}
+		for i, bound := range d.Bounds {
+			hu.Buckets = append(hu.Buckets, &HistogramBucket{
+				Bound: BucketLabel(bound),
+				Count: d.Counts[i],
+			})
+		}
+		for _, p := range h.Percentiles {
+			hu.Percentiles = append(hu.Percentiles, LinePoint{
+				Time: now.Unix(),
+				Y:    d.Percentile(p),
+			})
+		}
+		u.Histograms = append(u.Histograms, hu)
+	}
+
+	for _, t := range c.widgets.Tables {
+		tu := &TableUpdate{
+			ID:      t.ID(),
+			Columns: t.Labels(),
+			Rows:    []*TableRow{},
+		}
+		for _, s := range t.Sources(c.names()) {
+			row := &TableRow{Service: s, Cells: []*TableCell{}}
+			for _, col := range t.Columns {
+				v := col.Transform.Apply(s, ReadMetric(col.Metric, vars[s]), now)
+				row.Cells = append(row.Cells, TableCellValue(col, v))
+			}
+			tu.Rows = append(tu.Rows, row)
+		}
+		u.Tables = append(u.Tables, tu)
+	}
+
+	if len(c.alerts) > 0 {
+		u.Alerts = c.evaluateAlerts(vars, now)
+	}
+
 	return u
 }
 
-func GaugeValue(m *Metric, max int64, vars *Expvars) float64 {
-	if vars == nil {
-		return 0.0
+// evaluateAlerts updates the state of all alerts, notifies about the ones
+// that started firing or got resolved, and returns the firing ones.
+func (c *Crawler) evaluateAlerts(vars map[string]*Expvars, now time.Time) []*AlertUpdate {
+	names := c.names()
+
+	firing := []*AlertUpdate{}
+	for _, a := range c.alerts {
+		for _, s := range a.Sources(names) {
+			e := a.Evaluate(s, ReadMetric(a.Metric, vars[s]), now)
+			if e == nil {
+				continue
+			}
+
+			fmt.Println(e)
+			if c.notifier != nil {
+				c.notifier.Notify(e)
+			}
+		}
+		firing = append(firing, a.Firing()...)
+	}
+	return firing
+}
+
+// readWildcard reads the series of a line chart of a wildcard metric. The
+// series are named by the keys matched by numbers, prefixed by the service
+// when the chart shows several services. With an aggregate, the values of
+// each key are combined across the services. Series seen before but missing
+// now get no value.
+func (c *Crawler) readWildcard(ch *LineChart, vars map[string]*Expvars, now time.Time) *LineChartUpdate {
+	sources := ch.Sources(c.names())
+
+	values := map[string]interface{}{}
+	perKey := map[string][]interface{}{}
+	for _, s := range sources {
+		for key, m := range ch.Metric.Expand(vars[s]) {
+			v := ReadMetric(m, vars[s])
+			if _, ok := numeric(v); !ok {
+				// only numbers can be charted
+				continue
+			}
+			v = ch.Transform.Apply(s+": "+key, v, now)
+			switch {
+			case ch.Aggregate != nil:
+				perKey[key] = append(perKey[key], v)
+			case len(sources) == 1:
+				values[key] = v
+			default:
+				values[s+": "+key] = v
+			}
+		}
+	}
+	for key, vv := range perKey {
+		values[key] = ch.Aggregate.Apply(vv)
+	}
+
+	found := []string{}
+	for label := range values {
+		found = append(found, label)
+	}
+	sort.Strings(found)
+
+	lu := &LineChartUpdate{
+		ID:     ch.ID(),
+		Points: []LinePoint{},
+		Series: ch.Observe(found),
+	}
+	for _, label := range lu.Series {
+		lu.Points = append(lu.Points, LinePoint{
+			Time: now.Unix(),
+			Y:    LineChartValue(ch.Metric, values[label]),
+		})
+	}
+	return lu
+}
+
+// readWidget reads the transformed value of the metric of a widget that shows
+// either a single service or an aggregate across several services.
+func (c *Crawler) readWidget(m *Metric, t *Transform, a *Aggregate, service string, services []string, vars map[string]*Expvars, now time.Time) interface{} {
+	if a != nil {
+		return a.Apply(c.readAll(m, t, services, vars, now))
+	}
+	return t.Apply(service, ReadMetric(m, vars[service]), now)
+}
+
+// readAll reads the transformed value of the metric for each of the services,
+// or for all services if none are given.
+func (c *Crawler) readAll(m *Metric, t *Transform, services []string, vars map[string]*Expvars, now time.Time) []interface{} {
+	if len(services) == 0 {
+		services = c.names()
+	}
+
+	values := []interface{}{}
+	for _, s := range services {
+		values = append(values, t.Apply(s, ReadMetric(m, vars[s]), now))
+	}
+	return values
+}
+
+func GaugeValue(m *Metric, max int64, v interface{}) *float64 {
+	if v == nil {
+		return nil
 	}
 
-	v := ReadMetric(m, vars)
 	if value, ok := v.(int64); ok {
-		return float64(value) / float64(max)
+		value := float64(value) / float64(max)
+		return &value
 	}
 	if value, ok := v.(float64); ok {
-		return value / float64(max)
+		value := value / float64(max)
+		return &value
 	}
 
 	fmt.Printf("%s: usage of %s with gauge is not supported\n", m, reflect.TypeOf(v))
 
-	return 0.0
+	return nil
 }
 
-func LineChartValue(m *Metric, vars *Expvars) int64 {
-	if vars == nil {
-		return 0
+func LineChartValue(m *Metric, v interface{}) *float64 {
+	if v == nil {
+		return nil
 	}
 
-	v := ReadMetric(m, vars)
 	if value, ok := v.(int64); ok {
-		return value
+		value := float64(value)
+		return &value
+	}
+	if value, ok := v.(float64); ok {
+		return &value
 	}
 
 	fmt.Printf("%s: usage of %s with line-chart is not supported\n", m, reflect.TypeOf(v))
 
-	return 0
+	return nil
 }
 
-func TextValue(m *Metric, vars *Expvars) string {
-	if vars == nil {
-		return "N/A"
+// TableCellValue formats the value of a column of a Table.
+func TableCellValue(c *TableColumn, v interface{}) *TableCell {
+	value, text, level := formatted(c.Metric, c.Format, -1, c.Thresholds, v)
+	return &TableCell{
+		Value: value,
+		Text:  text,
+		Level: level,
 	}
+}
 
-	v := ReadMetric(m, vars)
-	if value, ok := v.(int64); ok {
-		return fmt.Sprintf("%d", value)
-	} else if value, ok := v.(float64); ok {
-		return fmt.Sprintf("%.2f", value)
-	} else if value, ok := v.(bool); ok {
-		return fmt.Sprintf("%t", value)
-	} else if value, ok := v.(string); ok {
-		return value
+// StatValue formats the value of a Stat, with its prefix and suffix.
+func StatValue(s *Stat, v interface{}) *StatUpdate {
+	decimals := -1
+	if s.Decimals != nil {
+		decimals = *s.Decimals
+	}
+	value, text, level := formatted(s.Metric, s.Format, decimals, s.Thresholds, v)
+	if value != nil {
+		t := s.Prefix + *text + s.Suffix
+		text = &t
+	}
+	return &StatUpdate{
+		Point: LinePoint{Y: value},
+		Text:  text,
+		Level: level,
 	}
+}
 
-	fmt.Printf("%s: usage of %s with gauge is not supported\n", m, reflect.TypeOf(v))
+// formatted formats a number in the format and returns it with the threshold
+// it reached. Values other than numbers are shown as they would be by a Text,
+// without a number.
+func formatted(m *Metric, format string, decimals int, t Thresholds, v interface{}) (*float64, *string, string) {
+	value, ok := numeric(v)
+	if !ok {
+		return nil, TextValue(m, v), ""
+	}
+
+	text := FormatNumber(format, decimals, value)
+	return &value, &text, t.Level(value)
+}
+
+// FormatValue formats a number with the default precision of the format.
+func FormatValue(format string, v float64) string {
+	return FormatNumber(format, -1, v)
+}
+
+// FormatNumber formats a number: bytes with binary units, ratios as
+// percents, nanoseconds as durations and other numbers with SI prefixes if
+// asked to. A negative number of decimals picks the default of the format.
+func FormatNumber(format string, decimals int, v float64) string {
+	fixed := func(v float64, precision int) string {
+		if decimals >= 0 {
+			precision = decimals
+		}
+		return strconv.FormatFloat(v, 'f', precision, 64)
+	}
+
+	switch format {
+	case BytesFormat:
+		units := []string{"B", "KiB", "MiB", "GiB", "TiB", "PiB"}
+		i := 0
+		for ; math.Abs(v) >= 1024 && i < len(units)-1; i++ {
+			v /= 1024
+		}
+		if i == 0 {
+			return fixed(v, 0) + " " + units[i]
+		}
+		return fixed(v, 1) + " " + units[i]
+	case PercentFormat:
+		return fixed(v*100, 1) + "%"
+	case DurationFormat:
+		d := time.Duration(v)
+		switch {
+		case d < time.Microsecond && d > -time.Microsecond:
+			return fixed(v, 0) + "ns"
+		case d < time.Millisecond && d > -time.Millisecond:
+			return fixed(v/float64(time.Microsecond), 1) + "µs"
+		case d < time.Second && d > -time.Second:
+			return fixed(v/float64(time.Millisecond), 1) + "ms"
+		case d < time.Minute && d > -time.Minute:
+			return fixed(v/float64(time.Second), 1) + "s"
+		}
+		return (d - d%time.Second).String()
+	case SIFormat:
+		prefixes := []string{"", "k", "M", "G", "T", "P", "E"}
+		i := 0
+		for ; math.Abs(v) >= 1000 && i < len(prefixes)-1; i++ {
+			v /= 1000
+		}
+		if i > 0 {
+			return fixed(v, 1) + prefixes[i]
+		}
+	}
+
+	if decimals < 0 && v == math.Trunc(v) && math.Abs(v) < 1e15 {
+		return fixed(v, 0)
+	}
+	return fixed(v, 2)
+}
+
+// isFormat tells whether numbers can be formatted as the given format.
+func isFormat(format string) bool {
+	switch format {
+	case "", NumberFormat, BytesFormat, PercentFormat, DurationFormat, SIFormat:
+		return true
+	}
+	return false
+}
 
-	return "N/A"
+func TextValue(m *Metric, v interface{}) *string {
+	if v == nil {
+		return nil
+	}
+
+	var value string
+	switch v := v.(type) {
+	case int64:
+		value = fmt.Sprintf("%d", v)
+	case float64:
+		value = fmt.Sprintf("%.2f", v)
+	case bool:
+		value = fmt.Sprintf("%t", v)
+	case string:
+		value = v
+	case []*jason.Value:
+		// arrays of strings, like cmdline, are joined by spaces
+		parts := []string{}
+		for _, item := range v {
+			s, err := item.String()
+			if err != nil {
+				fmt.Printf("%s: usage of arrays of %s with text is not supported\n", m, reflect.TypeOf(item.Interface()))
+				return nil
+			}
+			parts = append(parts, s)
+		}
+		value = strings.Join(parts, " ")
+	default:
+		fmt.Printf("%s: usage of %s with text is not supported\n", m, reflect.TypeOf(v))
+		return nil
+	}
+	return &value
 }
 
 func ReadMetric(m *Metric, vars *Expvars) interface{} {
+	if vars == nil {
+		return nil
+	}
+
+	if m.expr != nil {
+		v, integral, ok := m.expr.Eval(vars)
+		if !ok || math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil
+		}
+		if integral {
+			return int64(v)
+		}
+		return v
+	}
+
 	value, err := vars.GetValue(m.Path...)
 	if err != nil {
 		return nil
@@ -225,3 +1148,40 @@ func ReadMetric(m *Metric, vars *Expvars) interface{} {
 
 	return nil
 }
+
+// ReadPauses returns the garbage collection pauses recorded in memstats after
+// the given number of garbage collections, along with the current number.
+// The runtime keeps only the most recent pauses in a circular buffer, so
+// older ones are lost. A number lower than the given one means the service
+// restarted, and all its recorded pauses are new.
+func ReadPauses(vars *Expvars, since int64) ([]*GCPause, int64, bool) {
+	numGC, err := vars.GetInt64("memstats", "NumGC")
+	if err != nil {
+		return nil, 0, false
+	}
+	durations, err := vars.GetInt64Array("memstats", "PauseNs")
+	if err != nil || len(durations) == 0 {
+		return nil, 0, false
+	}
+	ends, err := vars.GetInt64Array("memstats", "PauseEnd")
+	if err != nil || len(ends) != len(durations) {
+		return nil, 0, false
+	}
+
+	if since > numGC {
+		since = 0
+	}
+	if size := int64(len(durations)); numGC-since > size {
+		since = numGC - size
+	}
+
+	pauses := []*GCPause{}
+	for n := since + 1; n <= numGC; n++ {
+		i := (n - 1) % int64(len(durations))
+		pauses = append(pauses, &GCPause{
+			End:      ends[i] / int64(time.Millisecond),
+			Duration: float64(durations[i]) / float64(time.Millisecond),
+		})
+	}
+	return pauses, numGC, true
+}
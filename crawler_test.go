@@ -5,6 +5,7 @@ import (
 
 	"time"
 
+	"encoding/json"
 	"errors"
 	"net/url"
 
@@ -17,6 +18,14 @@ func NewSafeMetric(name string) *Metric {
 	return m
 }
 
+func floatPtr(v float64) *float64 {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
 func WaitTime(ch chan bool, timeout time.Duration) error {
 	select {
 	case <-ch:
@@ -39,6 +48,21 @@ func (f *mockFetcher) Fetch(url url.URL) (*Expvars, error) {
 	return f.vars, f.err
 }
 
+// splitHealth separates the health of the services, which depends on the
+// latency of the crawls, from the rest of the updates.
+func splitHealth(t *testing.T, data []byte) (string, []*ServiceHealth) {
+	var updates WidgetsUpdates
+	assert.NoError(t, json.Unmarshal(data, &updates))
+
+	health := updates.Health
+	updates.Health = nil
+
+	data, err := json.Marshal(updates)
+	assert.NoError(t, err)
+
+	return string(data), health
+}
+
 func TestCrawler_Start_Success(t *testing.T) {
 	Now = func() time.Time {
 		t, _ := time.Parse("2006-Jan-02", "2013-Feb-03")
@@ -58,7 +82,7 @@ func TestCrawler_Start_Success(t *testing.T) {
 			vars: &Expvars{Object: o},
 		},
 		hub: &Hub{
-			dataCh: make(chan []byte, 1),
+			dataCh: make(chan *Message, 1),
 		},
 		services: []*Service{
 			{
@@ -97,7 +121,13 @@ func TestCrawler_Start_Success(t *testing.T) {
 	ch := make(chan bool)
 
 	go func() {
-		assert.Equal(t, `{"g":[{"i":"g1","v":0.8}],"lc":[{"i":"lc1","p":[{"time":1359849600,"y":123}]}],"t":[{"i":"t1","v":"text 1"}]}`, string(<-crawler.hub.dataCh))
+		updates, health := splitHealth(t, (<-crawler.hub.dataCh).Data)
+		assert.Equal(t, `{"g":[{"i":"g1","v":0.8}],"lc":[{"i":"lc1","p":[{"time":1359849600,"y":123}]}],"t":[{"i":"t1","v":"text 1"}]}`, updates)
+		if assert.Len(t, health, 1) {
+			assert.True(t, health[0].Up)
+			assert.Equal(t, int64(1359849600), health[0].LastSuccess)
+			assert.Equal(t, 0, health[0].Failures)
+		}
 
 		ch <- true
 	}()
@@ -123,7 +153,7 @@ func TestCrawler_Start_FetcherError(t *testing.T) {
 			err: assert.AnError,
 		},
 		hub: &Hub{
-			dataCh: make(chan []byte, 1),
+			dataCh: make(chan *Message, 1),
 		},
 		services: []*Service{
 			{
@@ -151,7 +181,13 @@ func TestCrawler_Start_FetcherError(t *testing.T) {
 	ch := make(chan bool)
 
 	go func() {
-		assert.Equal(t, `{"g":[{"i":"g1","v":0}],"lc":[],"t":[]}`, string(<-crawler.hub.dataCh))
+		updates, health := splitHealth(t, (<-crawler.hub.dataCh).Data)
+		assert.Equal(t, `{"g":[{"i":"g1","v":null}],"lc":[],"t":[]}`, updates)
+		if assert.Len(t, health, 1) {
+			assert.False(t, health[0].Up)
+			assert.Equal(t, assert.AnError.Error(), health[0].LastError)
+			assert.Equal(t, 1, health[0].Failures)
+		}
 
 		ch <- true
 	}()
@@ -168,7 +204,7 @@ func TestCrawler_Start_FetcherTimeout(t *testing.T) {
 			timeout: 1200 * time.Millisecond,
 		},
 		hub: &Hub{
-			dataCh: make(chan []byte, 1),
+			dataCh: make(chan *Message, 1),
 		},
 		services: []*Service{
 			{
@@ -196,7 +232,12 @@ func TestCrawler_Start_FetcherTimeout(t *testing.T) {
 	ch := make(chan bool)
 
 	go func() {
-		assert.Equal(t, `{"g":[{"i":"g1","v":0}],"lc":[],"t":[]}`, string(<-crawler.hub.dataCh))
+		updates, health := splitHealth(t, (<-crawler.hub.dataCh).Data)
+		assert.Equal(t, `{"g":[{"i":"g1","v":null}],"lc":[],"t":[]}`, updates)
+		if assert.Len(t, health, 1) {
+			assert.False(t, health[0].Up)
+			assert.Equal(t, "timed out after 1s", health[0].LastError)
+		}
 
 		ch <- true
 	}()
@@ -206,6 +247,277 @@ func TestCrawler_Start_FetcherTimeout(t *testing.T) {
 	}
 }
 
+func TestCrawler_Crawled(t *testing.T) {
+	o, err := jason.NewObjectFromBytes([]byte(`{"goroutines": 5}`))
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		fetcher:  &mockFetcher{vars: &Expvars{Object: o}},
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			Texts: []*Text{{cid: "t1", Metric: NewSafeMetric("goroutines"), Service: "service1"}},
+		},
+	}
+
+	vars := crawler.fetchAll()
+	assert.Len(t, vars, 1)
+	assert.Contains(t, vars, "service1")
+	assert.NotNil(t, crawler.Crawled("service1"))
+
+	// services that no widget shows are crawled once asked for
+	assert.Nil(t, crawler.Crawled("service2"))
+	vars = crawler.fetchAll()
+	assert.Len(t, vars, 2)
+	assert.Contains(t, vars, "service2")
+	if res := crawler.Crawled("service2"); assert.NotNil(t, res) {
+		assert.Equal(t, &Expvars{Object: o}, res.vars)
+		assert.NoError(t, res.err)
+	}
+}
+
+func TestCrawler_FetchAll_Alerts(t *testing.T) {
+	o, err := jason.NewObjectFromBytes([]byte(`{"goroutines": 500}`))
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		fetcher:  &mockFetcher{vars: &Expvars{Object: o}},
+		services: []*Service{{Name: "a"}, {Name: "b"}},
+		widgets: &Widgets{
+			Texts: []*Text{{cid: "t1", Metric: NewSafeMetric("goroutines"), Service: "a"}},
+		},
+		alerts: []*Alert{
+			{Name: "goroutines", Metric: NewSafeMetric("goroutines"), Service: "b", Comparison: ">", Threshold: 100},
+		},
+	}
+
+	// no widget shows service b, but the alert watches it
+	vars := crawler.fetchAll()
+	assert.Contains(t, vars, "b")
+
+	u := crawler.ExtractUpdates(vars)
+	if assert.Len(t, u.Alerts, 1) {
+		assert.Equal(t, "b", u.Alerts[0].Service)
+	}
+}
+
+func TestCrawler_Apply_History(t *testing.T) {
+	// parseConf accepts the scratch dashboard, as withScratch does
+	parse := func(data string) *Config {
+		var raw RawConfig
+		assert.NoError(t, json.Unmarshal([]byte(data), &raw))
+		conf, err := raw.parseConf()
+		assert.NoError(t, err)
+		return conf
+	}
+
+	conf := parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Sys"}}
+		]}]
+	}`)
+	crawler := &Crawler{
+		history:  NewHistory(time.Minute, time.Second),
+		services: conf.Services,
+		widgets:  conf.Widgets,
+	}
+	for _, u := range lineChartUpdates("c1", 1, 2) {
+		crawler.history.Add(u)
+	}
+	for _, u := range lineChartUpdates("c2", 1, 2) {
+		crawler.history.Add(u)
+	}
+	sys := crawler.history.Series("c2")
+
+	// the chart of memstats.Sys moved to the front, memstats.Alloc is gone
+	crawler.apply(&reload{conf: parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Sys"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.HeapAlloc"}}
+		]}]
+	}`)})
+
+	assert.Equal(t, sys, crawler.history.Series("c1"))
+	assert.Nil(t, crawler.history.Series("c2"))
+	assert.Equal(t, "memstats.Sys", crawler.widgets.LineCharts[0].Metric.String())
+
+	// a change of a single dashboard keeps the history of the others, even
+	// with storage
+	crawler.storage = &Storage{}
+	crawler.apply(&reload{dashboard: ScratchDashboard, conf: parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Sys"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.HeapAlloc"}}
+		]}],
+		"dashboards": [{"name": "scratch", "rows": [{"items": [{"type": "LineChart", "size": 6, "conf": {"metric": "goroutines"}}]}]}]
+	}`)})
+	assert.Equal(t, sys, crawler.history.Series("c1"))
+}
+
+func TestCrawler_Apply_History_SameKey(t *testing.T) {
+	parse := func(data string) *Config {
+		conf, err := ParseTestConf(t, data)
+		assert.NoError(t, err)
+		return conf
+	}
+
+	conf := parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}}
+		]}]
+	}`)
+	crawler := &Crawler{
+		history:  NewHistory(time.Minute, time.Second),
+		services: conf.Services,
+		widgets:  conf.Widgets,
+	}
+	for _, u := range lineChartUpdates("c1", 1, 2) {
+		crawler.history.Add(u)
+	}
+	for _, u := range lineChartUpdates("c2", 3, 4) {
+		crawler.history.Add(u)
+	}
+	first, second := crawler.history.Series("c1"), crawler.history.Series("c2")
+
+	// a new chart in front of both of them
+	crawler.apply(&reload{conf: parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Sys"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}},
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}}
+		]}]
+	}`)})
+
+	assert.Nil(t, crawler.history.Series("c1"))
+	assert.Equal(t, first, crawler.history.Series("c2"))
+	assert.Equal(t, second, crawler.history.Series("c3"))
+}
+
+func TestCrawler_Apply_History_Histograms(t *testing.T) {
+	parse := func(data string) *Config {
+		conf, err := ParseTestConf(t, data)
+		assert.NoError(t, err)
+		return conf
+	}
+
+	conf := parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "Histogram", "size": 6, "conf": {"metric": "latency", "percentiles": [50]}}
+		]}]
+	}`)
+	crawler := &Crawler{
+		history:  NewHistory(time.Minute, time.Second),
+		services: conf.Services,
+		widgets:  conf.Widgets,
+	}
+	id := crawler.widgets.Histograms[0].ID()
+	for i := 1; i <= 2; i++ {
+		crawler.history.Add(&WidgetsUpdates{Histograms: []*HistogramUpdate{
+			{ID: id, Percentiles: []LinePoint{{Time: int64(i), Y: floatPtr(float64(i))}}},
+		}})
+	}
+
+	// the histogram moved behind a new line chart
+	crawler.apply(&reload{conf: parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Sys"}},
+			{"type": "Histogram", "size": 6, "conf": {"metric": "latency", "percentiles": [50]}}
+		]}]
+	}`)})
+
+	id = crawler.widgets.Histograms[0].ID()
+	crawler.history.Add(&WidgetsUpdates{Histograms: []*HistogramUpdate{
+		{ID: id, Percentiles: []LinePoint{{Time: 3, Y: floatPtr(3)}}},
+	}})
+	assert.Equal(t, [][]LinePoint{{
+		{Time: 1, Y: floatPtr(1)},
+		{Time: 2, Y: floatPtr(2)},
+		{Time: 3, Y: floatPtr(3)},
+	}}, crawler.history.Snapshot().Histograms[0].History)
+}
+
+func TestCrawler_Apply_History_Stats(t *testing.T) {
+	parse := func(data string) *Config {
+		conf, err := ParseTestConf(t, data)
+		assert.NoError(t, err)
+		return conf
+	}
+
+	conf := parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "Stat", "size": 6, "conf": {"metric": "goroutines", "service": "service1"}}
+		]}]
+	}`)
+	crawler := &Crawler{
+		history:  NewHistory(time.Minute, time.Second),
+		services: conf.Services,
+		widgets:  conf.Widgets,
+	}
+	id := crawler.widgets.Stats[0].ID()
+	for i := 1; i <= 2; i++ {
+		crawler.history.Add(&WidgetsUpdates{Stats: []*StatUpdate{
+			{ID: id, Point: LinePoint{Time: int64(i), Y: floatPtr(float64(i))}},
+		}})
+	}
+
+	// the stat moved behind a new one and got a suffix
+	crawler.apply(&reload{conf: parse(`{
+		"services": [{"name": "service1", "url": "localhost:4004"}],
+		"rows": [{"items": [
+			{"type": "Stat", "size": 6, "conf": {"metric": "memstats.Sys", "service": "service1"}},
+			{"type": "Stat", "size": 6, "conf": {"metric": "goroutines", "service": "service1", "suffix": " routines"}}
+		]}]
+	}`)})
+
+	id = crawler.widgets.Stats[1].ID()
+	crawler.history.Add(&WidgetsUpdates{Stats: []*StatUpdate{
+		{ID: crawler.widgets.Stats[0].ID(), Point: LinePoint{Time: 3, Y: floatPtr(100)}},
+		{ID: id, Point: LinePoint{Time: 3, Y: floatPtr(3)}},
+	}})
+	stats := crawler.history.Snapshot().Stats
+	assert.Equal(t, []LinePoint{{Time: 3, Y: floatPtr(100)}}, stats[0].History)
+	assert.Equal(t, []LinePoint{
+		{Time: 1, Y: floatPtr(1)},
+		{Time: 2, Y: floatPtr(2)},
+		{Time: 3, Y: floatPtr(3)},
+	}, stats[1].History)
+}
+
+func TestCrawler_Publish_Dashboards(t *testing.T) {
+	crawler := &Crawler{
+		hub: &Hub{
+			dataCh: make(chan *Message, 2),
+		},
+		dashboards: []*Dashboard{
+			{Name: "a", ids: map[string]struct{}{"g1": {}}},
+			{Name: "b", ids: map[string]struct{}{"t1": {}}},
+		},
+	}
+
+	crawler.publish(&WidgetsUpdates{
+		Gauges:     []*GaugeUpdate{{ID: "g1", Value: floatPtr(0.8)}},
+		LineCharts: []*LineChartUpdate{},
+		Texts:      []*TextUpdate{{ID: "t1", Value: stringPtr("text 1")}},
+	})
+
+	message := <-crawler.hub.dataCh
+	assert.Equal(t, "a", message.Dashboard)
+	assert.Equal(t, `{"g":[{"i":"g1","v":0.8}],"lc":[],"t":[]}`, string(message.Data))
+
+	message = <-crawler.hub.dataCh
+	assert.Equal(t, "b", message.Dashboard)
+	assert.Equal(t, `{"g":[],"lc":[],"t":[{"i":"t1","v":"text 1"}]}`, string(message.Data))
+}
+
 func TestCrawler_ExtractUpdates(t *testing.T) {
 	Now = func() time.Time {
 		t, _ := time.Parse("2006-Jan-02", "2013-Feb-03")
@@ -299,15 +611,15 @@ func TestCrawler_ExtractUpdates(t *testing.T) {
 		Gauges: []*GaugeUpdate{
 			{
 				ID:    "g1",
-				Value: 0.8,
+				Value: floatPtr(0.8),
 			},
 			{
 				ID:    "g2",
-				Value: 0.6,
+				Value: floatPtr(0.6),
 			},
 			{
 				ID:    "g3",
-				Value: 0.0,
+				Value: nil,
 			},
 		},
 		LineCharts: []*LineChartUpdate{
@@ -316,15 +628,15 @@ func TestCrawler_ExtractUpdates(t *testing.T) {
 				Points: []LinePoint{
 					{
 						Time: 1359849600,
-						Y:    123,
+						Y:    floatPtr(123),
 					},
 					{
 						Time: 1359849600,
-						Y:    456,
+						Y:    floatPtr(456),
 					},
 					{
 						Time: 1359849600,
-						Y:    0,
+						Y:    nil,
 					},
 				},
 			},
@@ -333,7 +645,7 @@ func TestCrawler_ExtractUpdates(t *testing.T) {
 				Points: []LinePoint{
 					{
 						Time: 1359849600,
-						Y:    0,
+						Y:    nil,
 					},
 				},
 			},
@@ -342,7 +654,7 @@ func TestCrawler_ExtractUpdates(t *testing.T) {
 				Points: []LinePoint{
 					{
 						Time: 1359849600,
-						Y:    456,
+						Y:    floatPtr(456),
 					},
 				},
 			},
@@ -350,57 +662,544 @@ func TestCrawler_ExtractUpdates(t *testing.T) {
 		Texts: []*TextUpdate{
 			{
 				ID:    "t1",
-				Value: "text 1",
+				Value: stringPtr("text 1"),
 			},
 			{
 				ID:    "t2",
-				Value: "text 2",
+				Value: stringPtr("text 2"),
 			},
 			{
 				ID:    "t3",
-				Value: "N/A",
+				Value: nil,
 			},
 		},
 	}, updates)
 }
 
+func TestCrawler_ExtractUpdates_Aggregate(t *testing.T) {
+	Now = func() time.Time {
+		t, _ := time.Parse("2006-Jan-02", "2013-Feb-03")
+		return t
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	o1, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"alloc": 123}, "node": {"latency": 0.5}}`))
+	assert.NoError(t, err)
+	o2, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"alloc": 456}, "node": {"latency": 1.5}}`))
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		services: []*Service{
+			{
+				Name: "service1",
+			},
+			{
+				Name: "service2",
+			},
+			{
+				Name: "service3",
+			},
+		},
+		widgets: &Widgets{
+			Gauges: []*Gauge{
+				{
+					cid:       "g1",
+					Metric:    NewSafeMetric("node.latency"),
+					Aggregate: &Aggregate{Kind: MaxAggregate},
+					MaxValue:  2,
+				},
+			},
+			LineCharts: []*LineChart{
+				{
+					cid:       "lc1",
+					Metric:    NewSafeMetric("memstats.alloc"),
+					Aggregate: &Aggregate{Kind: SumAggregate},
+				},
+				{
+					cid:          "lc2",
+					Metric:       NewSafeMetric("memstats.alloc"),
+					Aggregate:    &Aggregate{Kind: AvgAggregate},
+					ShowServices: true,
+					Services:     []string{"service1", "service2"},
+				},
+			},
+			Texts: []*Text{
+				{
+					cid:       "t1",
+					Metric:    NewSafeMetric("memstats.alloc"),
+					Aggregate: &Aggregate{Kind: CountUpAggregate},
+				},
+			},
+		},
+	}
+
+	updates := crawler.ExtractUpdates(map[string]*Expvars{
+		"service1": {o1},
+		"service2": {o2},
+	})
+
+	assert.Equal(t, &WidgetsUpdates{
+		Gauges: []*GaugeUpdate{
+			{
+				ID:    "g1",
+				Value: floatPtr(0.75),
+			},
+		},
+		LineCharts: []*LineChartUpdate{
+			{
+				ID: "lc1",
+				Points: []LinePoint{
+					{
+						Time: 1359849600,
+						Y:    floatPtr(579),
+					},
+				},
+			},
+			{
+				ID: "lc2",
+				Points: []LinePoint{
+					{
+						Time: 1359849600,
+						Y:    floatPtr(123),
+					},
+					{
+						Time: 1359849600,
+						Y:    floatPtr(456),
+					},
+					{
+						Time: 1359849600,
+						Y:    floatPtr(289.5),
+					},
+				},
+			},
+		},
+		Texts: []*TextUpdate{
+			{
+				ID:    "t1",
+				Value: stringPtr("2"),
+			},
+		},
+	}, updates)
+}
+
+func TestCrawler_ExtractUpdates_Wildcard(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			LineCharts: []*LineChart{
+				{cid: "lc1", Metric: NewSafeMetric("handlers.*.count"), Services: []string{"service1"}},
+				{cid: "lc2", Metric: NewSafeMetric("handlers.*.count")},
+				{cid: "lc3", Metric: NewSafeMetric("handlers.*.count"), Aggregate: &Aggregate{Kind: SumAggregate}},
+			},
+		},
+	}
+
+	crawl := func(data1, data2 string) []*LineChartUpdate {
+		o1, err := jason.NewObjectFromBytes([]byte(data1))
+		assert.NoError(t, err)
+		o2, err := jason.NewObjectFromBytes([]byte(data2))
+		assert.NoError(t, err)
+
+		return crawler.ExtractUpdates(map[string]*Expvars{"service1": {o1}, "service2": {o2}}).LineCharts
+	}
+
+	updates := crawl(
+		`{"handlers": {"users": {"count": 1}, "orders": {"count": 2}}}`,
+		`{"handlers": {"users": {"count": 3}}}`,
+	)
+	assert.Equal(t, []*LineChartUpdate{
+		{ID: "lc1", Series: []string{"orders", "users"}, Points: []LinePoint{
+			{Time: 1000, Y: floatPtr(2)}, {Time: 1000, Y: floatPtr(1)},
+		}},
+		{ID: "lc2", Series: []string{"service1: orders", "service1: users", "service2: users"}, Points: []LinePoint{
+			{Time: 1000, Y: floatPtr(2)}, {Time: 1000, Y: floatPtr(1)}, {Time: 1000, Y: floatPtr(3)},
+		}},
+		{ID: "lc3", Series: []string{"orders", "users"}, Points: []LinePoint{
+			{Time: 1000, Y: floatPtr(2)}, {Time: 1000, Y: floatPtr(4)},
+		}},
+	}, updates)
+
+	// new keys are added after the known ones, missing keys keep their place
+	updates = crawl(
+		`{"handlers": {"users": {"count": 5}, "admin": {"count": 6}}}`,
+		`{}`,
+	)
+	assert.Equal(t, []*LineChartUpdate{
+		{ID: "lc1", Series: []string{"orders", "users", "admin"}, Points: []LinePoint{
+			{Time: 1000, Y: nil}, {Time: 1000, Y: floatPtr(5)}, {Time: 1000, Y: floatPtr(6)},
+		}},
+		{ID: "lc2", Series: []string{"service1: orders", "service1: users", "service2: users", "service1: admin"}, Points: []LinePoint{
+			{Time: 1000, Y: nil}, {Time: 1000, Y: floatPtr(5)}, {Time: 1000, Y: nil}, {Time: 1000, Y: floatPtr(6)},
+		}},
+		{ID: "lc3", Series: []string{"orders", "users", "admin"}, Points: []LinePoint{
+			{Time: 1000, Y: nil}, {Time: 1000, Y: floatPtr(5)}, {Time: 1000, Y: floatPtr(6)},
+		}},
+	}, updates)
+}
+
+func TestCrawler_ExtractUpdates_GCPauses(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			GCPauses: []*GCPauses{
+				{cid: "gc1", MaxValue: 10, Buckets: 5},
+				{cid: "gc2", Services: []string{"service2"}, MaxValue: 10, Buckets: 5},
+			},
+		},
+	}
+
+	crawl := func(data1, data2 string) []*GCPausesUpdate {
+		o1, err := jason.NewObjectFromBytes([]byte(data1))
+		assert.NoError(t, err)
+		o2, err := jason.NewObjectFromBytes([]byte(data2))
+		assert.NoError(t, err)
+
+		return crawler.ExtractUpdates(map[string]*Expvars{"service1": {o1}, "service2": {o2}}).GCPauses
+	}
+
+	// the first crawl only remembers the number of garbage collections
+	updates := crawl(
+		`{"memstats": {"NumGC": 1, "PauseNs": [1000000, 0], "PauseEnd": [5000000000, 0]}}`,
+		`{"memstats": {"NumGC": 0, "PauseNs": [0, 0], "PauseEnd": [0, 0]}}`,
+	)
+	assert.Equal(t, []*GCPausesUpdate{
+		{ID: "gc1", Time: 1000, Pauses: []*GCPause{}, Max: 10, Buckets: 5},
+		{ID: "gc2", Time: 1000, Pauses: []*GCPause{}, Max: 10, Buckets: 5},
+	}, updates)
+
+	updates = crawl(
+		`{"memstats": {"NumGC": 3, "PauseNs": [3000000, 2000000], "PauseEnd": [7000000000, 6000000000]}}`,
+		`{"memstats": {"NumGC": 1, "PauseNs": [500000, 0], "PauseEnd": [8000000000, 0]}}`,
+	)
+	assert.Equal(t, []*GCPausesUpdate{
+		{ID: "gc1", Time: 1000, Max: 10, Buckets: 5, Pauses: []*GCPause{
+			{Service: "service1", End: 6000, Duration: 2},
+			{Service: "service1", End: 7000, Duration: 3},
+			{Service: "service2", End: 8000, Duration: 0.5},
+		}},
+		{ID: "gc2", Time: 1000, Max: 10, Buckets: 5, Pauses: []*GCPause{
+			{Service: "service2", End: 8000, Duration: 0.5},
+		}},
+	}, updates)
+}
+
+func TestCrawler_ExtractUpdates_Stat(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	decimals := 0
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			Stats: []*Stat{
+				{cid: "sv1", Metric: NewSafeMetric("memstats.Alloc"), Service: "service1", Format: BytesFormat, Thresholds: Thresholds{Warning: floatPtr(1024)}},
+				{cid: "sv2", Metric: NewSafeMetric("goroutines"), Aggregate: &Aggregate{Kind: SumAggregate}, Decimals: &decimals, Prefix: "~", Suffix: " goroutines"},
+				{cid: "sv3", Metric: NewSafeMetric("version"), Service: "service2"},
+				{cid: "sv4", Metric: NewSafeMetric("missing"), Service: "service2"},
+			},
+		},
+	}
+
+	o1, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"Alloc": 2048}, "goroutines": 10.5}`))
+	assert.NoError(t, err)
+	o2, err := jason.NewObjectFromBytes([]byte(`{"goroutines": 20, "version": "1.2"}`))
+	assert.NoError(t, err)
+
+	updates := crawler.ExtractUpdates(map[string]*Expvars{"service1": {o1}, "service2": {o2}})
+	assert.Equal(t, []*StatUpdate{
+		{ID: "sv1", Point: LinePoint{Time: 1000, Y: floatPtr(2048)}, Text: stringPtr("2.0 KiB"), Level: "warning"},
+		{ID: "sv2", Point: LinePoint{Time: 1000, Y: floatPtr(30.5)}, Text: stringPtr("~30 goroutines")},
+		{ID: "sv3", Point: LinePoint{Time: 1000}, Text: stringPtr("1.2")},
+		{ID: "sv4", Point: LinePoint{Time: 1000}},
+	}, updates.Stats)
+}
+
+func TestThresholds_Level(t *testing.T) {
+	higher := Thresholds{Warning: floatPtr(10), Critical: floatPtr(20)}
+	assert.Equal(t, "", higher.Level(5))
+	assert.Equal(t, "warning", higher.Level(10))
+	assert.Equal(t, "critical", higher.Level(25))
+
+	lower := Thresholds{Warning: floatPtr(0.5), Critical: floatPtr(0.1)}
+	assert.Equal(t, "", lower.Level(0.9))
+	assert.Equal(t, "warning", lower.Level(0.3))
+	assert.Equal(t, "critical", lower.Level(0.1))
+
+	assert.Equal(t, "", Thresholds{}.Level(100))
+}
+
+func TestCrawler_ExtractUpdates_BarChart(t *testing.T) {
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}, {Name: "service3"}},
+		widgets: &Widgets{
+			BarCharts: []*BarChart{
+				{cid: "bc1", Metric: NewSafeMetric("goroutines")},
+				{cid: "bc2", Metric: NewSafeMetric("goroutines"), Sort: DescendingOrder, Horizontal: true},
+				{cid: "bc3", Metric: NewSafeMetric("goroutines"), Sort: AscendingOrder, Services: []string{"service3", "service1"}},
+			},
+		},
+	}
+
+	vars := map[string]*Expvars{}
+	for name, data := range map[string]string{
+		"service1": `{"goroutines": 10}`,
+		"service2": `{}`,
+		"service3": `{"goroutines": 30}`,
+	} {
+		o, err := jason.NewObjectFromBytes([]byte(data))
+		assert.NoError(t, err)
+		vars[name] = &Expvars{o}
+	}
+
+	updates := crawler.ExtractUpdates(vars)
+	assert.Equal(t, []*BarChartUpdate{
+		{ID: "bc1", Bars: []*Bar{
+			{Service: "service1", Value: floatPtr(10)},
+			{Service: "service2", Value: nil},
+			{Service: "service3", Value: floatPtr(30)},
+		}},
+		{ID: "bc2", Horizontal: true, Bars: []*Bar{
+			{Service: "service3", Value: floatPtr(30)},
+			{Service: "service1", Value: floatPtr(10)},
+			{Service: "service2", Value: nil},
+		}},
+		{ID: "bc3", Bars: []*Bar{
+			{Service: "service1", Value: floatPtr(10)},
+			{Service: "service3", Value: floatPtr(30)},
+		}},
+	}, updates.BarCharts)
+}
+
+func TestCrawler_ExtractUpdates_Histogram(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}},
+		widgets: &Widgets{
+			Histograms: []*Histogram{
+				{cid: "hg1", Metric: NewSafeMetric("latency"), Percentiles: []float64{50, 90}},
+			},
+		},
+	}
+
+	o, err := jason.NewObjectFromBytes([]byte(`{"latency": {"10": 8, "100": 2}}`))
+	assert.NoError(t, err)
+
+	updates := crawler.ExtractUpdates(map[string]*Expvars{"service1": {o}})
+	assert.Equal(t, []*HistogramUpdate{
+		{
+			ID:          "hg1",
+			Buckets:     []*HistogramBucket{{Bound: "10", Count: 8}, {Bound: "100", Count: 2}},
+			Percentiles: []LinePoint{{Time: 1000, Y: floatPtr(6.25)}, {Time: 1000, Y: floatPtr(55)}},
+		},
+	}, updates.Histograms)
+}
+
+func TestCrawler_ExtractUpdates_Table(t *testing.T) {
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			Tables: []*Table{
+				{cid: "tb1", Columns: []*TableColumn{
+					{Metric: NewSafeMetric("memstats.Alloc"), Label: "Heap", Format: BytesFormat, Thresholds: Thresholds{Warning: floatPtr(1024), Critical: floatPtr(1048576)}},
+					{Metric: NewSafeMetric("ratio"), Label: "Ratio", Format: PercentFormat, Thresholds: Thresholds{Warning: floatPtr(0.5), Critical: floatPtr(0.1)}},
+					{Metric: NewSafeMetric("version"), Label: "Version"},
+				}},
+			},
+		},
+	}
+
+	o1, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"Alloc": 2048}, "ratio": 0.05, "version": "1.2"}`))
+	assert.NoError(t, err)
+	o2, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"Alloc": 512}, "ratio": 0.75}`))
+	assert.NoError(t, err)
+
+	updates := crawler.ExtractUpdates(map[string]*Expvars{"service1": {o1}, "service2": {o2}})
+	assert.Equal(t, []*TableUpdate{
+		{ID: "tb1", Columns: []string{"Heap", "Ratio", "Version"}, Rows: []*TableRow{
+			{Service: "service1", Cells: []*TableCell{
+				{Value: floatPtr(2048), Text: stringPtr("2.0 KiB"), Level: "warning"},
+				{Value: floatPtr(0.05), Text: stringPtr("5.0%"), Level: "critical"},
+				{Text: stringPtr("1.2")},
+			}},
+			{Service: "service2", Cells: []*TableCell{
+				{Value: floatPtr(512), Text: stringPtr("512 B")},
+				{Value: floatPtr(0.75), Text: stringPtr("75.0%")},
+				{},
+			}},
+		}},
+	}, updates.Tables)
+}
+
+func TestFormatValue(t *testing.T) {
+	tests := []struct {
+		format string
+		value  float64
+		want   string
+	}{
+		{"", 42, "42"},
+		{NumberFormat, 3.14159, "3.14"},
+		{BytesFormat, 1000, "1000 B"},
+		{BytesFormat, 1536, "1.5 KiB"},
+		{BytesFormat, 3 * 1024 * 1024 * 1024, "3.0 GiB"},
+		{PercentFormat, 0.123, "12.3%"},
+		{DurationFormat, 500, "500ns"},
+		{DurationFormat, 1500000, "1.5ms"},
+		{DurationFormat, 2500000000, "2.5s"},
+		{DurationFormat, 3723000000000, "1h2m3s"},
+		{SIFormat, 999, "999"},
+		{SIFormat, 1250000, "1.2M"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatValue(tt.format, tt.value), "%s %v", tt.format, tt.value)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		format   string
+		decimals int
+		value    float64
+		want     string
+	}{
+		{NumberFormat, 0, 3.6, "4"},
+		{NumberFormat, 3, 42, "42.000"},
+		{BytesFormat, 2, 1536, "1.50 KiB"},
+		{PercentFormat, 0, 0.123, "12%"},
+		{DurationFormat, 0, 1500000, "2ms"},
+		{SIFormat, 3, 1234567, "1.235M"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, FormatNumber(tt.format, tt.decimals, tt.value), "%s %v", tt.format, tt.value)
+	}
+}
+
+func TestReadPauses(t *testing.T) {
+	tests := []struct {
+		name   string
+		vars   string
+		since  int64
+		pauses []*GCPause
+		numGC  int64
+		ok     bool
+	}{
+		{
+			name: "no memstats",
+			vars: `{}`,
+		},
+		{
+			name:   "new pauses",
+			vars:   `{"memstats": {"NumGC": 2, "PauseNs": [1000000, 2000000, 0], "PauseEnd": [1000000000, 2000000000, 0]}}`,
+			since:  1,
+			pauses: []*GCPause{{End: 2000, Duration: 2}},
+			numGC:  2,
+			ok:     true,
+		},
+		{
+			name:   "wrapped around",
+			vars:   `{"memstats": {"NumGC": 4, "PauseNs": [4000000, 2000000, 3000000], "PauseEnd": [4000000000, 2000000000, 3000000000]}}`,
+			since:  2,
+			pauses: []*GCPause{{End: 3000, Duration: 3}, {End: 4000, Duration: 4}},
+			numGC:  4,
+			ok:     true,
+		},
+		{
+			name:   "overwritten pauses are lost",
+			vars:   `{"memstats": {"NumGC": 5, "PauseNs": [5000000, 4000000], "PauseEnd": [5000000000, 4000000000]}}`,
+			since:  1,
+			pauses: []*GCPause{{End: 4000, Duration: 4}, {End: 5000, Duration: 5}},
+			numGC:  5,
+			ok:     true,
+		},
+		{
+			name:   "restarted service",
+			vars:   `{"memstats": {"NumGC": 1, "PauseNs": [1000000, 0], "PauseEnd": [1000000000, 0]}}`,
+			since:  10,
+			pauses: []*GCPause{{End: 1000, Duration: 1}},
+			numGC:  1,
+			ok:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, err := jason.NewObjectFromBytes([]byte(tt.vars))
+			assert.NoError(t, err)
+
+			pauses, numGC, ok := ReadPauses(&Expvars{o}, tt.since)
+			assert.Equal(t, tt.pauses, pauses)
+			assert.Equal(t, tt.numGC, numGC)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
 func TestGaugeValue(t *testing.T) {
 	m := NewSafeMetric("test.metric")
 
 	tests := []struct {
 		name string
 		vars string
-		want float64
+		want *float64
 	}{
 		{
 			name: "read non existing value",
 			vars: `{}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read int64 value",
 			vars: `{"test": {"metric": 747}}`,
-			want: 0.747,
+			want: floatPtr(0.747),
 		},
 		{
 			name: "read float64 value",
 			vars: `{"test": {"metric": 74.7}}`,
-			want: 0.0747,
+			want: floatPtr(0.0747),
 		},
 		{
 			name: "read bool value",
 			vars: `{"test": {"metric": true}}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read string value",
 			vars: `{"test": {"metric": "hello"}}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read array value",
 			vars: `{"test": {"metric": [1,2,3]}}`,
-			want: 0,
+			want: nil,
 		},
 	}
 	for _, tt := range tests {
@@ -410,9 +1209,7 @@ func TestGaugeValue(t *testing.T) {
 
 			vars := &Expvars{o}
 
-			if got := GaugeValue(m, 1000, vars); got != tt.want {
-				t.Errorf("GaugeValue() = %v, want %v", got, tt.want)
-			}
+			assert.Equal(t, tt.want, GaugeValue(m, 1000, ReadMetric(m, vars)))
 		})
 	}
 }
@@ -423,37 +1220,37 @@ func TestLineChartValue(t *testing.T) {
 	tests := []struct {
 		name string
 		vars string
-		want int64
+		want *float64
 	}{
 		{
 			name: "read non existing value",
 			vars: `{}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read int64 value",
 			vars: `{"test": {"metric": 747}}`,
-			want: 747,
+			want: floatPtr(747),
 		},
 		{
 			name: "read float64 value",
 			vars: `{"test": {"metric": 36.6}}`,
-			want: 0,
+			want: floatPtr(36.6),
 		},
 		{
 			name: "read bool value",
 			vars: `{"test": {"metric": true}}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read string value",
 			vars: `{"test": {"metric": "hello"}}`,
-			want: 0,
+			want: nil,
 		},
 		{
 			name: "read array value",
 			vars: `{"test": {"metric": [1,2,3]}}`,
-			want: 0,
+			want: nil,
 		},
 	}
 	for _, tt := range tests {
@@ -463,9 +1260,7 @@ func TestLineChartValue(t *testing.T) {
 
 			vars := &Expvars{o}
 
-			if got := LineChartValue(m, vars); got != tt.want {
-				t.Errorf("GaugeValue() = %v, want %v", got, tt.want)
-			}
+			assert.Equal(t, tt.want, LineChartValue(m, ReadMetric(m, vars)))
 		})
 	}
 }
@@ -476,37 +1271,42 @@ func TestTextValue(t *testing.T) {
 	tests := []struct {
 		name string
 		vars string
-		want string
+		want *string
 	}{
 		{
 			name: "read non existing value",
 			vars: `{}`,
-			want: "N/A",
+			want: nil,
 		},
 		{
 			name: "read int64 value",
 			vars: `{"test": {"metric": 747}}`,
-			want: "747",
+			want: stringPtr("747"),
 		},
 		{
 			name: "read float64 value",
 			vars: `{"test": {"metric": 36.6}}`,
-			want: "36.60",
+			want: stringPtr("36.60"),
 		},
 		{
 			name: "read bool value",
 			vars: `{"test": {"metric": true}}`,
-			want: "true",
+			want: stringPtr("true"),
 		},
 		{
 			name: "read string value",
 			vars: `{"test": {"metric": "hello"}}`,
-			want: "hello",
+			want: stringPtr("hello"),
 		},
 		{
 			name: "read array value",
 			vars: `{"test": {"metric": [1,2,3]}}`,
-			want: "N/A",
+			want: nil,
+		},
+		{
+			name: "read array of strings value",
+			vars: `{"test": {"metric": ["./service", "-p", "4004"]}}`,
+			want: stringPtr("./service -p 4004"),
 		},
 	}
 	for _, tt := range tests {
@@ -516,9 +1316,7 @@ func TestTextValue(t *testing.T) {
 
 			vars := &Expvars{o}
 
-			if got := TextValue(m, vars); got != tt.want {
-				t.Errorf("GaugeValue() = %v, want %v", got, tt.want)
-			}
+			assert.Equal(t, tt.want, TextValue(m, ReadMetric(m, vars)))
 		})
 	}
 }
@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Export is the data shown by a widget as a table. Missing values are nil.
+type Export struct {
+	ID      string          `json:"id"`
+	Title   string          `json:"title"`
+	Columns []string        `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// ExportWidget exports the retained points of a line chart, or the latest
+// values of the other widgets.
+func ExportWidget(widget Widget, title string, history *History, all []string) *Export {
+	e := &Export{
+		ID:    widget.ID(),
+		Title: title,
+		Rows:  [][]interface{}{},
+	}
+
+	last, updated := history.Last()
+	if last == nil {
+		last = &WidgetsUpdates{}
+	}
+
+	switch widget := widget.(type) {
+	case *LineChart:
+		e.Columns = append([]string{"time"}, widget.Series(all)...)
+
+		series := history.Series(widget.ID())
+		for i := 0; len(series) > 0 && i < len(series[0]); i++ {
+			row := []interface{}{series[0][i].Time}
+			for _, s := range series {
+				if i < len(s) && s[i].Y != nil {
+					row = append(row, *s[i].Y)
+				} else {
+					row = append(row, nil)
+				}
+			}
+			e.Rows = append(e.Rows, row)
+		}
+	case *Gauge:
+		e.Columns = []string{"time", "value"}
+		for _, g := range last.Gauges {
+			if g.ID == widget.ID() {
+				e.Rows = append(e.Rows, []interface{}{updated.Unix(), floatValue(g.Value)})
+			}
+		}
+	case *Text:
+		e.Columns = []string{"time", "value"}
+		for _, t := range last.Texts {
+			if t.ID == widget.ID() {
+				var v interface{}
+				if t.Value != nil {
+					v = *t.Value
+				}
+				e.Rows = append(e.Rows, []interface{}{updated.Unix(), v})
+			}
+		}
+	case *Status:
+		e.Columns = []string{"service", "up", "last_success", "last_error", "failures", "latency_ms"}
+		for _, st := range last.Statuses {
+			if st.ID != widget.ID() {
+				continue
+			}
+			for _, h := range st.Services {
+				e.Rows = append(e.Rows, []interface{}{h.Service, h.Up, h.LastSuccess, h.LastError, h.Failures, h.Latency})
+			}
+		}
+	case *Stat:
+		e.Columns = []string{"time", "value"}
+		for _, sv := range last.Stats {
+			if sv.ID != widget.ID() {
+				continue
+			}
+			var v interface{}
+			if sv.Point.Y != nil {
+				v = *sv.Point.Y
+			} else if sv.Text != nil {
+				v = *sv.Text
+			}
+			e.Rows = append(e.Rows, []interface{}{updated.Unix(), v})
+		}
+	case *BarChart:
+		e.Columns = []string{"service", "value"}
+		for _, bc := range last.BarCharts {
+			if bc.ID != widget.ID() {
+				continue
+			}
+			for _, b := range bc.Bars {
+				e.Rows = append(e.Rows, []interface{}{b.Service, floatValue(b.Value)})
+			}
+		}
+	case *Histogram:
+		e.Columns = []string{"bound", "count"}
+		for _, hg := range last.Histograms {
+			if hg.ID != widget.ID() {
+				continue
+			}
+			for _, b := range hg.Buckets {
+				e.Rows = append(e.Rows, []interface{}{b.Bound, b.Count})
+			}
+		}
+	case *Table:
+		e.Columns = append([]string{"service"}, widget.Labels()...)
+		for _, tb := range last.Tables {
+			if tb.ID != widget.ID() {
+				continue
+			}
+			for _, r := range tb.Rows {
+				row := []interface{}{r.Service}
+				for _, c := range r.Cells {
+					switch {
+					case c.Value != nil:
+						row = append(row, *c.Value)
+					case c.Text != nil:
+						row = append(row, *c.Text)
+					default:
+						row = append(row, nil)
+					}
+				}
+				e.Rows = append(e.Rows, row)
+			}
+		}
+	case *GCPauses:
+		e.Columns = []string{"service", "end", "pause_ms"}
+		for _, gc := range last.GCPauses {
+			if gc.ID != widget.ID() {
+				continue
+			}
+			for _, p := range gc.Pauses {
+				e.Rows = append(e.Rows, []interface{}{p.Service, p.End, p.Duration})
+			}
+		}
+	}
+
+	return e
+}
+
+func floatValue(v *float64) interface{} {
+	if v == nil {
+		return nil
+	}
+	return *v
+}
+
+// WriteCSV writes the columns and the rows of the export, leaving missing
+// values empty.
+func (e *Export) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(e.Columns); err != nil {
+		return err
+	}
+
+	for _, row := range e.Rows {
+		record := []string{}
+		for _, v := range row {
+			switch v := v.(type) {
+			case nil:
+				record = append(record, "")
+			case float64:
+				record = append(record, strconv.FormatFloat(v, 'f', -1, 64))
+			default:
+				record = append(record, fmt.Sprint(v))
+			}
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// export downloads the data of the widget with the ID in the path, as JSON
+// or, with format=csv, as CSV.
+func (a *API) export(w http.ResponseWriter, r *http.Request) {
+	id := strings.TrimPrefix(r.URL.Path, "/api/export/")
+	conf := a.reloader.Config()
+
+	var widget Widget
+	for _, c := range conf.Widgets.All() {
+		if c.ID() == id {
+			widget = c
+		}
+	}
+	if widget == nil {
+		writeJSON(w, http.StatusNotFound, &apiError{fmt.Sprintf("Unknown widget: %s", id)})
+		return
+	}
+
+	title := widget.Title()
+	for _, d := range conf.Dashboards {
+		for _, row := range d.Layout.Rows {
+			for _, col := range row.Cols {
+				if col.ID == id {
+					title = col.Title
+				}
+			}
+		}
+	}
+
+	e := ExportWidget(widget, title, a.history, serviceNames(conf))
+
+	switch format := r.URL.Query().Get("format"); format {
+	case "", "json":
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.json", id))
+		writeJSON(w, http.StatusOK, e)
+	case "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s.csv", id))
+		if err := e.WriteCSV(w); err != nil {
+			fmt.Println("Error writing export:", err)
+		}
+	default:
+		writeJSON(w, http.StatusBadRequest, &apiError{fmt.Sprintf("Unknown format: %s", format)})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_WriteCSV(t *testing.T) {
+	e := &Export{
+		Columns: []string{"time", "service1", "service2"},
+		Rows: [][]interface{}{
+			{int64(1000), 1.5, nil},
+			{int64(1010), 2.0, "a,b"},
+		},
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(t, e.WriteCSV(&buf))
+	assert.Equal(t, "time,service1,service2\n1000,1.5,\n1010,2,\"a,b\"\n", buf.String())
+}
+
+func TestAPI_Export(t *testing.T) {
+	server, api := testAPI(t)
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		want       string
+	}{
+		{
+			name:       "line chart as json",
+			path:       "/api/export/c1",
+			wantStatus: http.StatusOK,
+			want:       `{"id":"c1","title":"memstats.Alloc","columns":["time","service1","service2","sum"],"rows":[[1000,0,null,0],[1010,1,null,1],[1020,2,null,2],[1030,3,null,3]]}`,
+		},
+		{
+			name:       "line chart as csv",
+			path:       "/api/export/c1?format=csv",
+			wantStatus: http.StatusOK,
+			want:       "time,service1,service2,sum\n1000,0,,0\n1010,1,,1\n1020,2,,2\n1030,3,,3\n",
+		},
+		{
+			name:       "text",
+			path:       "/api/export/c2?format=csv",
+			wantStatus: http.StatusOK,
+			want:       "time,value\n2000,5\n",
+		},
+		{
+			name:       "unknown widget",
+			path:       "/api/export/c9",
+			wantStatus: http.StatusNotFound,
+			want:       `{"error":"Unknown widget: c9"}`,
+		},
+		{
+			name:       "unknown format",
+			path:       "/api/export/c1?format=xml",
+			wantStatus: http.StatusBadRequest,
+			want:       `{"error":"Unknown format: xml"}`,
+		},
+	}
+
+	Now = func() time.Time {
+		return time.Unix(2000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	api.history.Add(&WidgetsUpdates{
+		Texts: []*TextUpdate{{ID: "c2", Value: stringPtr("5")}},
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := get(t, server.URL+tt.path)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.want, body)
+		})
+	}
+}
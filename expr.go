@@ -0,0 +1,339 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// An expression computes a metric from the values of other metrics, e.g.
+// "memstats.HeapInuse / memstats.HeapSys * 100". It supports +, -, *, /,
+// parentheses, numeric constants and the min, max and abs functions.
+// Paths that contain characters other than letters, digits, '_' and '.'
+// have to be quoted: 'node.Request-Count'.
+type expr interface {
+	// Eval returns the value of the expression and whether the value is
+	// an integer. It fails if any of the values is missing or not numeric.
+	Eval(vars *Expvars) (float64, bool, bool)
+}
+
+type numberExpr struct {
+	value    float64
+	integral bool
+}
+
+func (e *numberExpr) Eval(vars *Expvars) (float64, bool, bool) {
+	return e.value, e.integral, true
+}
+
+type pathExpr struct {
+	path []string
+}
+
+func (e *pathExpr) Eval(vars *Expvars) (float64, bool, bool) {
+	switch v := ReadMetric(&Metric{Path: e.path}, vars).(type) {
+	case int64:
+		return float64(v), true, true
+	case float64:
+		return v, false, true
+	}
+	return 0, false, false
+}
+
+type negExpr struct {
+	arg expr
+}
+
+func (e *negExpr) Eval(vars *Expvars) (float64, bool, bool) {
+	v, integral, ok := e.arg.Eval(vars)
+	return -v, integral, ok
+}
+
+type binaryExpr struct {
+	op          byte
+	left, right expr
+}
+
+func (e *binaryExpr) Eval(vars *Expvars) (float64, bool, bool) {
+	l, lint, ok := e.left.Eval(vars)
+	if !ok {
+		return 0, false, false
+	}
+	r, rint, ok := e.right.Eval(vars)
+	if !ok {
+		return 0, false, false
+	}
+
+	switch e.op {
+	case '+':
+		return l + r, lint && rint, true
+	case '-':
+		return l - r, lint && rint, true
+	case '*':
+		return l * r, lint && rint, true
+	case '/':
+		if r == 0 {
+			return 0, false, false
+		}
+		return l / r, false, true
+	}
+	return 0, false, false
+}
+
+type callExpr struct {
+	name string
+	args []expr
+}
+
+var exprFuncs = map[string]struct {
+	minArgs, maxArgs int
+}{
+	"abs": {1, 1},
+	"min": {1, -1},
+	"max": {1, -1},
+}
+
+func (e *callExpr) Eval(vars *Expvars) (float64, bool, bool) {
+	result, integral := 0.0, true
+	for i, arg := range e.args {
+		v, vint, ok := arg.Eval(vars)
+		if !ok {
+			return 0, false, false
+		}
+		integral = integral && vint
+
+		switch {
+		case e.name == "abs":
+			result = math.Abs(v)
+		case i == 0:
+			result = v
+		case e.name == "min":
+			result = math.Min(result, v)
+		case e.name == "max":
+			result = math.Max(result, v)
+		}
+	}
+	return result, integral, true
+}
+
+const (
+	tokenEOF = iota
+	tokenNumber
+	tokenPath
+	tokenOp
+)
+
+type token struct {
+	kind  int
+	text  string
+	value float64
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func isPathChar(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+func tokenize(s string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case strings.ContainsRune("+-*/(),", r):
+			tokens = append(tokens, token{kind: tokenOp, text: string(r), pos: i})
+			i++
+		case r == '\'':
+			end := i + 1
+			for end < len(runes) && runes[end] != '\'' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, fmt.Errorf("unterminated quoted path at position %d", i)
+			}
+			tokens = append(tokens, token{kind: tokenPath, text: string(runes[i+1 : end]), pos: i})
+			i = end + 1
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			end := i
+			for end < len(runes) && (unicode.IsDigit(runes[end]) || runes[end] == '.' || runes[end] == 'e' || runes[end] == 'E' ||
+				(runes[end] == '-' || runes[end] == '+') && (runes[end-1] == 'e' || runes[end-1] == 'E')) {
+				end++
+			}
+			text := string(runes[i:end])
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid number %q at position %d", text, i)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: i})
+			i = end
+		case isPathChar(r):
+			end := i
+			for end < len(runes) && isPathChar(runes[end]) {
+				end++
+			}
+			// keys like http-requests are common, so a path that runs into
+			// an operator is more likely a typo than an operation
+			if end < len(runes) && strings.ContainsRune("+-*/", runes[end]) {
+				return nil, fmt.Errorf("ambiguous %q after path %q at position %d, quote the path or put spaces around the operator", runes[end], string(runes[i:end]), end)
+			}
+			tokens = append(tokens, token{kind: tokenPath, text: string(runes[i:end]), pos: i})
+			i = end
+		default:
+			return nil, fmt.Errorf("unexpected character %q at position %d", r, i)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// ParseExpr parses an arithmetic expression over expvar paths.
+func ParseExpr(s string) (expr, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	e, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokenEOF {
+		return nil, p.unexpected(t)
+	}
+	return e, nil
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isOp(ops string) bool {
+	t := p.peek()
+	return t.kind == tokenOp && strings.Contains(ops, t.text)
+}
+
+func (p *parser) unexpected(t token) error {
+	return fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+func (p *parser) parseSum() (expr, error) {
+	left, err := p.parseProduct()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("+-") {
+		op := p.next().text[0]
+		right, err := p.parseProduct()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseProduct() (expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.isOp("*/") {
+		op := p.next().text[0]
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryExpr{op: op, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.isOp("-") {
+		p.next()
+		arg, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &negExpr{arg: arg}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.next()
+	switch {
+	case t.kind == tokenNumber:
+		return &numberExpr{value: t.value, integral: !strings.ContainsAny(t.text, ".eE")}, nil
+	case t.kind == tokenPath && p.isOp("("):
+		return p.parseCall(t)
+	case t.kind == tokenPath:
+		return &pathExpr{path: strings.Split(t.text, ".")}, nil
+	case t.kind == tokenOp && t.text == "(":
+		e, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		if !p.isOp(")") {
+			return nil, p.unexpected(p.peek())
+		}
+		p.next()
+		return e, nil
+	}
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) parseCall(name token) (expr, error) {
+	f, ok := exprFuncs[name.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown function %q at position %d", name.text, name.pos)
+	}
+	p.next()
+
+	call := &callExpr{name: name.text}
+	for !p.isOp(")") {
+		if len(call.args) > 0 {
+			if !p.isOp(",") {
+				return nil, p.unexpected(p.peek())
+			}
+			p.next()
+		}
+		arg, err := p.parseSum()
+		if err != nil {
+			return nil, err
+		}
+		call.args = append(call.args, arg)
+	}
+	p.next()
+
+	if len(call.args) < f.minArgs || f.maxArgs > 0 && len(call.args) > f.maxArgs {
+		return nil, fmt.Errorf("wrong number of arguments to %s at position %d", name.text, name.pos)
+	}
+	return call, nil
+}
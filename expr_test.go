@@ -0,0 +1,217 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/antonholmquist/jason"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetric_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		metric  string
+		wantErr string
+	}{
+		{
+			name:    "empty",
+			metric:  "",
+			wantErr: `Invalid metric "": unexpected end of expression at position 0`,
+		},
+		{
+			name:    "dangling operator",
+			metric:  "a.b +",
+			wantErr: `Invalid metric "a.b +": unexpected end of expression at position 5`,
+		},
+		{
+			name:    "unbalanced parentheses",
+			metric:  "(a.b + 1",
+			wantErr: `Invalid metric "(a.b + 1": unexpected end of expression at position 8`,
+		},
+		{
+			name:    "unexpected character",
+			metric:  "a.b % 2",
+			wantErr: `Invalid metric "a.b % 2": unexpected character '%' at position 4`,
+		},
+		{
+			name:    "unknown function",
+			metric:  "avg(a, b)",
+			wantErr: `Invalid metric "avg(a, b)": unknown function "avg" at position 0`,
+		},
+		{
+			name:    "wrong number of arguments",
+			metric:  "abs(a, b)",
+			wantErr: `Invalid metric "abs(a, b)": wrong number of arguments to abs at position 0`,
+		},
+		{
+			name:    "path running into an operator",
+			metric:  "(node.Request-Count + 1)",
+			wantErr: `Invalid metric "(node.Request-Count + 1)": ambiguous '-' after path "node.Request" at position 13, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "path running into a multiplication",
+			metric:  "x*2 + 1",
+			wantErr: `Invalid metric "x*2 + 1": ambiguous '*' after path "x" at position 1, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "multiplication in path",
+			metric:  "memstats.HeapInuse*100",
+			wantErr: `Invalid metric "memstats.HeapInuse*100": ambiguous '*' in path at position 18, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "division in path",
+			metric:  "errors/requests",
+			wantErr: `Invalid metric "errors/requests": ambiguous '/' in path at position 6, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "addition in path",
+			metric:  "hits+misses",
+			wantErr: `Invalid metric "hits+misses": ambiguous '+' in path at position 4, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "wildcard within a key",
+			metric:  "handlers.api*.count",
+			wantErr: `Invalid metric "handlers.api*.count": ambiguous '*' in path at position 12, quote the path or put spaces around the operator`,
+		},
+		{
+			name:    "unterminated quote",
+			metric:  "'a-b",
+			wantErr: `Invalid metric "'a-b": unterminated quoted path at position 0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMetric(tt.metric)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadMetric_Expression(t *testing.T) {
+	o, err := jason.NewObjectFromBytes([]byte(`{
+		"memstats": {"HeapInuse": 300, "HeapSys": 1200},
+		"node": {"Request-Count": 7, "Latency": 0.5, "Zero": 0, "Name": "node"},
+		"handlers": {"/api/users": {"count": 3}}
+	}`))
+	assert.NoError(t, err)
+
+	vars := &Expvars{o}
+
+	tests := []struct {
+		name   string
+		metric string
+		want   interface{}
+	}{
+		{
+			name:   "plain path",
+			metric: "memstats.HeapSys",
+			want:   int64(1200),
+		},
+		{
+			name:   "path with a minus",
+			metric: "node.Request-Count",
+			want:   int64(7),
+		},
+		{
+			name:   "quoted path with slashes",
+			metric: "'handlers./api/users.count'",
+			want:   int64(3),
+		},
+		{
+			name:   "ratio",
+			metric: "memstats.HeapInuse / memstats.HeapSys * 100",
+			want:   float64(25),
+		},
+		{
+			name:   "integer arithmetic",
+			metric: "memstats.HeapSys - memstats.HeapInuse * 2",
+			want:   int64(600),
+		},
+		{
+			name:   "precedence and parentheses",
+			metric: "(1 + 2) * -3 + 10",
+			want:   int64(1),
+		},
+		{
+			name:   "float constant",
+			metric: "node.Latency * 1e3",
+			want:   float64(500),
+		},
+		{
+			name:   "quoted path",
+			metric: "'node.Request-Count' + 1",
+			want:   int64(8),
+		},
+		{
+			name:   "functions",
+			metric: "max(abs(-5), min(memstats.HeapInuse, 4), 2)",
+			want:   int64(5),
+		},
+		{
+			name:   "division by zero",
+			metric: "memstats.HeapInuse / node.Zero",
+			want:   nil,
+		},
+		{
+			name:   "missing variable",
+			metric: "memstats.HeapInuse + memstats.Missing",
+			want:   nil,
+		},
+		{
+			name:   "non numeric variable",
+			metric: "node.Name * 2",
+			want:   nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := NewMetric(tt.metric)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, ReadMetric(m, vars))
+		})
+	}
+}
+
+func TestMetric_String(t *testing.T) {
+	m, err := NewMetric(" memstats.HeapInuse / memstats.HeapSys ")
+	assert.NoError(t, err)
+	assert.Equal(t, "memstats.HeapInuse / memstats.HeapSys", m.String())
+
+	m, err = NewMetric("'memstats.HeapInuse'")
+	assert.NoError(t, err)
+	assert.Equal(t, "memstats.HeapInuse", m.String())
+}
+
+func TestMetric_Expand(t *testing.T) {
+	vars, err := ParseExpvars(strings.NewReader(`{
+		"handlers": {"users": {"count": 1}, "orders": {"count": 2}, "health": {"ok": true}},
+		"queues": {"a": {"b": 3, "c": 4}}
+	}`))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		metric string
+		want   map[string]string
+	}{
+		{"handlers.*.count", map[string]string{"users": "handlers.users.count", "orders": "handlers.orders.count"}},
+		{"queues.*.*", map[string]string{"a.b": "queues.a.b", "a.c": "queues.a.c"}},
+		{"missing.*", map[string]string{}},
+	}
+	for _, tt := range tests {
+		m, err := NewMetric(tt.metric)
+		assert.NoError(t, err)
+		assert.True(t, m.Wildcard())
+
+		got := map[string]string{}
+		for key, expanded := range m.Expand(vars) {
+			got[key] = expanded.String()
+		}
+		assert.Equal(t, tt.want, got, tt.metric)
+	}
+
+	m, err := NewMetric("memstats.Alloc * 2")
+	assert.NoError(t, err)
+	assert.False(t, m.Wildcard())
+}
@@ -2,8 +2,10 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/antonholmquist/jason"
@@ -19,6 +21,9 @@ type Fetcher interface {
 
 type fetcher struct {
 	client *http.Client
+	header http.Header
+	auth   func(req *http.Request) error
+	parse  func(r io.Reader) (*Expvars, error)
 }
 
 func NewFetcher() Fetcher {
@@ -26,6 +31,7 @@ func NewFetcher() Fetcher {
 		client: &http.Client{
 			Timeout: time.Second,
 		},
+		parse: ParseExpvars,
 	}
 }
 
@@ -35,6 +41,22 @@ func (f *fetcher) Fetch(url url.URL) (*Expvars, error) {
 		return nil, err
 	}
 
+	for name, values := range f.header {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if host := f.header.Get("Host"); host != "" {
+		req.Host = host
+	}
+
+	if f.auth != nil {
+		err = f.auth(req)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	resp, err := f.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -45,10 +67,68 @@ func (f *fetcher) Fetch(url url.URL) (*Expvars, error) {
 		return nil, fmt.Errorf("Could not fetch expvars from %s", url.String())
 	}
 
-	object, err := jason.NewObjectFromReader(resp.Body)
+	return f.parse(resp.Body)
+}
+
+// BasicAuth authenticates requests with the username and password.
+func BasicAuth(username, password string) func(req *http.Request) error {
+	return func(req *http.Request) error {
+		req.SetBasicAuth(username, password)
+		return nil
+	}
+}
+
+// BearerAuth authenticates requests with the token returned by the function,
+// which is called for every request, so that rotated tokens are picked up.
+func BearerAuth(token func() (string, error)) func(req *http.Request) error {
+	return func(req *http.Request) error {
+		t, err := token()
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", "Bearer "+t)
+		return nil
+	}
+}
+
+func ParseExpvars(r io.Reader) (*Expvars, error) {
+	object, err := jason.NewObjectFromReader(r)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Expvars{object}, nil
 }
+
+// Paths lists the paths of all numbers, booleans and strings in the
+// variables.
+func (v *Expvars) Paths() []string {
+	paths := []string{}
+	v.Walk(func(path string, value *jason.Value) {
+		if _, err := value.Array(); err != nil {
+			paths = append(paths, path)
+		}
+	})
+	sort.Strings(paths)
+	return paths
+}
+
+// Walk calls the function with the path and the value of every variable that
+// isn't an object or null, in no particular order.
+func (v *Expvars) Walk(fn func(path string, value *jason.Value)) {
+	walk(v.Object, "", fn)
+}
+
+func walk(o *jason.Object, prefix string, fn func(path string, value *jason.Value)) {
+	for key, value := range o.Map() {
+		path := prefix + key
+		if obj, err := value.Object(); err == nil {
+			walk(obj, path+".", fn)
+			continue
+		}
+		if err := value.Null(); err == nil {
+			continue
+		}
+		fn(path, value)
+	}
+}
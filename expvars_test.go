@@ -1,11 +1,14 @@
 package main
 
 import (
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
 	"testing"
 	"time"
 
@@ -97,3 +100,188 @@ func TestFetcher_Fetch_Success(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, &Expvars{o}, vars)
 }
+
+func TestFetcher_Fetch_Credentials(t *testing.T) {
+	tokenFile, err := ioutil.TempFile("", "token")
+	assert.NoError(t, err)
+	defer os.Remove(tokenFile.Name())
+	fmt.Fprintln(tokenFile, "file-token")
+	tokenFile.Close()
+
+	os.Setenv("EXPVARDASH_TEST_TOKEN", "env-token")
+	defer os.Unsetenv("EXPVARDASH_TEST_TOKEN")
+
+	tests := []struct {
+		name       string
+		raw        RawService
+		wantHeader string
+		want       string
+	}{
+		{
+			name: "custom header",
+			raw: RawService{
+				Headers: map[string]string{"X-Api-Key": "secret"},
+			},
+			wantHeader: "X-Api-Key",
+			want:       "secret",
+		},
+		{
+			name: "basic auth",
+			raw: RawService{
+				BasicAuth: &RawBasicAuth{Username: "user", Password: "pass"},
+			},
+			wantHeader: "Authorization",
+			want:       "Basic dXNlcjpwYXNz",
+		},
+		{
+			name: "bearer token",
+			raw: RawService{
+				BearerToken: "token",
+			},
+			wantHeader: "Authorization",
+			want:       "Bearer token",
+		},
+		{
+			name: "bearer token from file",
+			raw: RawService{
+				BearerTokenFile: tokenFile.Name(),
+			},
+			wantHeader: "Authorization",
+			want:       "Bearer file-token",
+		},
+		{
+			name: "bearer token from environment",
+			raw: RawService{
+				BearerTokenEnv: "EXPVARDASH_TEST_TOKEN",
+			},
+			wantHeader: "Authorization",
+			want:       "Bearer env-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tearUp()
+			defer tearDown()
+
+			mux.HandleFunc("/debug/vars", func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(tt.wantHeader) != tt.want {
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				fmt.Fprint(w, `{"alloc": 123}`)
+			})
+
+			tt.raw.Name = "service-1"
+			tt.raw.URL = server.URL
+			s, err := ReadService(tt.raw)
+			assert.NoError(t, err)
+
+			_, err = s.Fetcher.Fetch(s.URL)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestFetcher_Fetch_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"alloc": 123}`)
+	}))
+	defer server.Close()
+
+	caFile, err := ioutil.TempFile("", "ca")
+	assert.NoError(t, err)
+	defer os.Remove(caFile.Name())
+	pem.Encode(caFile, &pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile.Close()
+
+	tests := []struct {
+		name    string
+		tls     *RawTLS
+		wantErr bool
+	}{
+		{
+			name:    "unknown authority",
+			tls:     nil,
+			wantErr: true,
+		},
+		{
+			name: "custom CA",
+			tls:  &RawTLS{CAFile: caFile.Name()},
+		},
+		{
+			name: "insecure skip verify",
+			tls:  &RawTLS{InsecureSkipVerify: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ReadService(RawService{
+				Name: "service-1",
+				URL:  server.URL,
+				TLS:  tt.tls,
+			})
+			assert.NoError(t, err)
+
+			_, err = s.Fetcher.Fetch(s.URL)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestReadService_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     RawService
+		wantErr string
+	}{
+		{
+			name:    "invalid timeout",
+			raw:     RawService{Timeout: "soon"},
+			wantErr: "Invalid timeout of service service-1: soon",
+		},
+		{
+			name:    "basic auth and bearer token",
+			raw:     RawService{BasicAuth: &RawBasicAuth{}, BearerToken: "token"},
+			wantErr: "Invalid configuration of service service-1: basic_auth and bearer token can't be used together",
+		},
+		{
+			name:    "several bearer tokens",
+			raw:     RawService{BearerToken: "token", BearerTokenEnv: "TOKEN"},
+			wantErr: "Invalid configuration of service service-1: only one of bearer_token, bearer_token_file and bearer_token_env can be set",
+		},
+		{
+			name:    "missing environment variable",
+			raw:     RawService{BearerTokenEnv: "EXPVARDASH_MISSING_TOKEN"},
+			wantErr: "Invalid configuration of service service-1: environment variable EXPVARDASH_MISSING_TOKEN is not set",
+		},
+		{
+			name:    "certificate without key",
+			raw:     RawService{TLS: &RawTLS{CertFile: "client.crt"}},
+			wantErr: "Invalid configuration of service service-1: both cert_file and key_file are required for a client certificate",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.raw.Name = "service-1"
+			tt.raw.URL = "localhost:4004"
+
+			_, err := ReadService(tt.raw)
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReadService_Timeout(t *testing.T) {
+	s, err := ReadService(RawService{
+		Name:    "service-1",
+		URL:     "localhost:4004",
+		Timeout: "3s",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3*time.Second, s.Timeout)
+	assert.Equal(t, 3*time.Second, s.Fetcher.(*fetcher).client.Timeout)
+}
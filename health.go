@@ -0,0 +1,50 @@
+package main
+
+import (
+	"time"
+)
+
+// ServiceHealth is the outcome of the recent crawls of a service.
+type ServiceHealth struct {
+	Service     string  `json:"s"`
+	Up          bool    `json:"u"`
+	LastSuccess int64   `json:"t"`
+	LastError   string  `json:"e,omitempty"`
+	Failures    int     `json:"f"`
+	Latency     float64 `json:"l"`
+}
+
+type StatusUpdate struct {
+	ID       string           `json:"i"`
+	Services []*ServiceHealth `json:"s"`
+}
+
+// Record updates the health with the result of a crawl. The latency is kept
+// in milliseconds.
+func (h *ServiceHealth) Record(err error, latency time.Duration, now time.Time) {
+	h.Latency = latency.Seconds() * 1000
+
+	if err != nil {
+		h.Up = false
+		h.LastError = err.Error()
+		h.Failures++
+		return
+	}
+
+	h.Up = true
+	h.LastSuccess = now.Unix()
+	h.Failures = 0
+}
+
+// Health returns a copy of the health of every crawled service, in the order
+// of the given services.
+func Health(health map[string]*ServiceHealth, services []string) []*ServiceHealth {
+	list := []*ServiceHealth{}
+	for _, s := range services {
+		if h, ok := health[s]; ok {
+			c := *h
+			list = append(list, &c)
+		}
+	}
+	return list
+}
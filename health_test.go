@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceHealth_Record(t *testing.T) {
+	h := &ServiceHealth{Service: "service1"}
+
+	h.Record(nil, 20*time.Millisecond, time.Unix(1000, 0))
+	assert.Equal(t, &ServiceHealth{Service: "service1", Up: true, LastSuccess: 1000, Latency: 20}, h)
+
+	h.Record(errors.New("connection refused"), 5*time.Millisecond, time.Unix(1005, 0))
+	h.Record(errors.New("connection refused"), 5*time.Millisecond, time.Unix(1010, 0))
+	assert.Equal(t, &ServiceHealth{Service: "service1", Up: false, LastSuccess: 1000, LastError: "connection refused", Failures: 2, Latency: 5}, h)
+
+	h.Record(nil, 10*time.Millisecond, time.Unix(1015, 0))
+	assert.Equal(t, &ServiceHealth{Service: "service1", Up: true, LastSuccess: 1015, LastError: "connection refused", Latency: 10}, h)
+}
+
+func TestCrawler_ExtractUpdates_Status(t *testing.T) {
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}, {Name: "service3"}},
+		widgets: &Widgets{
+			Statuses: []*Status{
+				{cid: "s1"},
+				{cid: "s2", Services: []string{"service2"}},
+			},
+		},
+		health: map[string]*ServiceHealth{
+			"service1": {Service: "service1", Up: true, LastSuccess: 1000},
+			"service2": {Service: "service2", LastError: "timed out after 1s", Failures: 3},
+		},
+	}
+
+	u := crawler.ExtractUpdates(map[string]*Expvars{})
+
+	assert.Equal(t, []*StatusUpdate{
+		{
+			ID: "s1",
+			Services: []*ServiceHealth{
+				{Service: "service1", Up: true, LastSuccess: 1000},
+				{Service: "service2", LastError: "timed out after 1s", Failures: 3},
+			},
+		},
+		{
+			ID: "s2",
+			Services: []*ServiceHealth{
+				{Service: "service2", LastError: "timed out after 1s", Failures: 3},
+			},
+		},
+	}, u.Statuses)
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/antonholmquist/jason"
+)
+
+// Distribution is a histogram: the counts of the values up to the upper
+// bounds of its buckets, in ascending order. The values of the first bucket
+// start at the lower bound, the last upper bound may be +Inf.
+type Distribution struct {
+	Lower  float64
+	Bounds []float64
+	Counts []float64
+}
+
+// NewDistribution builds a distribution from counts keyed by upper bounds.
+func NewDistribution(lower float64, counts map[float64]float64) *Distribution {
+	d := &Distribution{
+		Lower:  lower,
+		Bounds: []float64{},
+		Counts: []float64{},
+	}
+	for bound := range counts {
+		d.Bounds = append(d.Bounds, bound)
+	}
+	sort.Float64s(d.Bounds)
+	for _, bound := range d.Bounds {
+		d.Counts = append(d.Counts, counts[bound])
+	}
+	if len(d.Bounds) > 0 && d.Bounds[0] < d.Lower {
+		d.Lower = d.Bounds[0]
+	}
+	return d
+}
+
+// Percentile estimates the value below which the given percent of the
+// values fall, assuming the values are spread evenly within their bucket.
+// Values in an unbounded bucket are assumed to be at its lower bound.
+func (d *Distribution) Percentile(p float64) *float64 {
+	total := 0.0
+	for _, c := range d.Counts {
+		total += c
+	}
+	if total == 0 {
+		return nil
+	}
+
+	rank := p / 100 * total
+	seen := 0.0
+	for i, c := range d.Counts {
+		if c == 0 || seen+c < rank {
+			seen += c
+			continue
+		}
+
+		lower := d.Lower
+		if i > 0 {
+			lower = d.Bounds[i-1]
+		}
+		upper := d.Bounds[i]
+		if math.IsInf(upper, 1) {
+			return &lower
+		}
+		v := lower + (upper-lower)*(rank-seen)/c
+		return &v
+	}
+
+	last := d.Bounds[len(d.Bounds)-1]
+	return &last
+}
+
+// readBuckets reads a histogram published as a map of the upper bounds of the
+// buckets to their counts, like {"10": 3, "100": 1, "+Inf": 0}. The buckets
+// of Prometheus histograms, like {"le=10": 3, "le=+Inf": 4}, count all values
+// up to their bound.
+func readBuckets(value *jason.Value) (map[float64]float64, bool) {
+	o, err := value.Object()
+	if err != nil {
+		return nil, false
+	}
+
+	counts := map[float64]float64{}
+	cumulative := false
+	for key, v := range o.Map() {
+		if strings.HasPrefix(key, "le=") {
+			key = key[len("le="):]
+			cumulative = true
+		}
+		bound, err := strconv.ParseFloat(strings.TrimSpace(key), 64)
+		if err != nil {
+			fmt.Printf("Invalid bucket of histogram: %s\n", key)
+			return nil, false
+		}
+		count, err := v.Float64()
+		if err != nil {
+			fmt.Printf("Invalid count of bucket %s of histogram: %v\n", key, v.Interface())
+			return nil, false
+		}
+		counts[bound] = count
+	}
+	if cumulative {
+		counts = uncumulate(counts)
+	}
+	return counts, true
+}
+
+// uncumulate turns the counts of the values up to the bounds into the counts
+// of the values within each bucket.
+func uncumulate(counts map[float64]float64) map[float64]float64 {
+	bounds := []float64{}
+	for bound := range counts {
+		bounds = append(bounds, bound)
+	}
+	sort.Float64s(bounds)
+
+	within := map[float64]float64{}
+	previous := 0.0
+	for _, bound := range bounds {
+		within[bound] = counts[bound] - previous
+		previous = counts[bound]
+	}
+	return within
+}
+
+// readSamples reads a histogram published as an array of samples.
+func readSamples(value *jason.Value) ([]float64, bool) {
+	items, err := value.Array()
+	if err != nil {
+		return nil, false
+	}
+
+	samples := []float64{}
+	for _, item := range items {
+		v, err := item.Float64()
+		if err != nil {
+			return nil, false
+		}
+		samples = append(samples, v)
+	}
+	return samples, true
+}
+
+// binSamples counts the samples in the given number of buckets of equal
+// width, spanning from the smallest to the largest sample.
+func binSamples(samples []float64, buckets int, counts map[float64]float64) {
+	if len(samples) == 0 {
+		return
+	}
+
+	min, max := samples[0], samples[0]
+	for _, s := range samples {
+		min = math.Min(min, s)
+		max = math.Max(max, s)
+	}
+	if min == max {
+		counts[max]++
+		return
+	}
+
+	width := (max - min) / float64(buckets)
+	for _, s := range samples {
+		i := int((s - min) / width)
+		if i >= buckets {
+			i = buckets - 1
+		}
+		counts[min+float64(i+1)*width]++
+	}
+}
+
+// deltaBuckets returns the counts added to the buckets since the previous
+// crawl. Counts that went down mean the service restarted.
+func deltaBuckets(previous, counts map[float64]float64) map[float64]float64 {
+	delta := map[float64]float64{}
+	for bound, count := range counts {
+		if count < previous[bound] {
+			return counts
+		}
+		delta[bound] = count - previous[bound]
+	}
+	return delta
+}
+
+// BucketLabel names a bucket by its upper bound.
+func BucketLabel(bound float64) string {
+	if math.IsInf(bound, 1) {
+		return "+Inf"
+	}
+	return FormatValue(NumberFormat, bound)
+}
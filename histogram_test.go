@@ -0,0 +1,66 @@
+package main
+
+import (
+	"math"
+	"testing"
+
+	"github.com/antonholmquist/jason"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistribution_Percentile(t *testing.T) {
+	d := NewDistribution(0, map[float64]float64{10: 50, 100: 40, math.Inf(1): 10})
+
+	tests := []struct {
+		p    float64
+		want *float64
+	}{
+		{0, floatPtr(0)},
+		{25, floatPtr(5)},
+		{50, floatPtr(10)},
+		{70, floatPtr(55)},
+		{95, floatPtr(100)},
+		{100, floatPtr(100)},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, d.Percentile(tt.p), "p%v", tt.p)
+	}
+
+	assert.Nil(t, NewDistribution(0, map[float64]float64{10: 0}).Percentile(50))
+}
+
+func TestHistogram_Distribution(t *testing.T) {
+	crawl := func(h *Histogram, data1, data2 string) *Distribution {
+		o1, err := jason.NewObjectFromBytes([]byte(data1))
+		assert.NoError(t, err)
+		o2, err := jason.NewObjectFromBytes([]byte(data2))
+		assert.NoError(t, err)
+
+		vars := map[string]*Expvars{"service1": {o1}, "service2": {o2}}
+		return h.Distribution([]string{"service1", "service2"}, vars)
+	}
+
+	h := &Histogram{Metric: NewSafeMetric("latency"), Buckets: 4}
+	d := crawl(h, `{"latency": {"10": 1, "100": 2, "+Inf": 0}}`, `{"latency": {"10": 3}}`)
+	assert.Equal(t, &Distribution{Bounds: []float64{10, 100, math.Inf(1)}, Counts: []float64{4, 2, 0}}, d)
+
+	d = crawl(h, `{"latency": [10, 20, 30, 50]}`, `{"latency": [18]}`)
+	assert.Equal(t, &Distribution{Lower: 10, Bounds: []float64{20, 30, 40, 50}, Counts: []float64{2, 1, 1, 1}}, d)
+
+	h = &Histogram{Metric: NewSafeMetric("latency"), Delta: true}
+	d = crawl(h, `{"latency": {"10": 1, "100": 2}}`, `{}`)
+	assert.Equal(t, &Distribution{Bounds: []float64{}, Counts: []float64{}}, d)
+
+	d = crawl(h, `{"latency": {"10": 4, "100": 2}}`, `{"latency": {"10": 1}}`)
+	assert.Equal(t, &Distribution{Bounds: []float64{10, 100}, Counts: []float64{3, 0}}, d)
+
+	// the service restarted
+	d = crawl(h, `{"latency": {"10": 1, "100": 1}}`, `{}`)
+	assert.Equal(t, &Distribution{Bounds: []float64{10, 100}, Counts: []float64{1, 1}}, d)
+}
+
+func TestBucketLabel(t *testing.T) {
+	assert.Equal(t, "250", BucketLabel(250))
+	assert.Equal(t, "0.50", BucketLabel(0.5))
+	assert.Equal(t, "+Inf", BucketLabel(math.Inf(1)))
+}
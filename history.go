@@ -0,0 +1,245 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+type LineChartHistory struct {
+	ID     string        `json:"i"`
+	Series [][]LinePoint `json:"s"`
+	Labels []string      `json:"l,omitempty"`
+}
+
+// History keeps the line-chart points of the last retention window for
+// every widget, so that clients joining the hub can be backfilled.
+// The sparklines of stats and the percentiles of histograms are kept apart
+// from the line charts.
+type History struct {
+	mu        sync.RWMutex
+	size      int
+	retention time.Duration
+	charts    map[string]*ring
+	order     []string
+	lines     map[string]*ring
+	last      *WidgetsUpdates
+	updated   time.Time
+}
+
+func NewHistory(retention, interval time.Duration) *History {
+	size := 0
+	if interval > 0 {
+		size = int(retention / interval)
+	}
+
+	return &History{
+		size:      size,
+		retention: retention,
+		charts:    make(map[string]*ring),
+		lines:     make(map[string]*ring),
+	}
+}
+
+func (h *History) Retention() time.Duration {
+	return h.retention
+}
+
+func (h *History) Add(u *WidgetsUpdates) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.last = u
+	h.updated = Now()
+
+	if h.size == 0 {
+		return
+	}
+
+	for _, lc := range u.LineCharts {
+		r, ok := h.charts[lc.ID]
+		if !ok {
+			r = newRing(h.size)
+			h.charts[lc.ID] = r
+			h.order = append(h.order, lc.ID)
+		}
+		r.Push(lc.Points)
+		if lc.Series != nil {
+			r.labels = lc.Series
+		}
+	}
+
+	for _, hg := range u.Histograms {
+		if len(hg.Percentiles) > 0 {
+			h.push(hg.ID, hg.Percentiles)
+		}
+	}
+	for _, sv := range u.Stats {
+		h.push(sv.ID, []LinePoint{sv.Point})
+	}
+}
+
+// push retains the points of a widget other than a line chart.
+func (h *History) push(id string, points []LinePoint) {
+	r, ok := h.lines[id]
+	if !ok {
+		r = newRing(h.size)
+		h.lines[id] = r
+	}
+	r.Push(points)
+}
+
+// Rekey keeps the retained points of the charts in the map under their new
+// IDs and forgets the others, along with the latest updates.
+func (h *History) Rekey(ids map[string]string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	charts := make(map[string]*ring)
+	order := []string{}
+	for _, id := range h.order {
+		if to, ok := ids[id]; ok {
+			charts[to] = h.charts[id]
+			order = append(order, to)
+		}
+	}
+	h.charts = charts
+	h.order = order
+
+	lines := make(map[string]*ring)
+	for id, r := range h.lines {
+		if to, ok := ids[id]; ok {
+			lines[to] = r
+		}
+	}
+	h.lines = lines
+	h.last = nil
+}
+
+// Reset forgets everything, e.g. when the widgets have changed.
+func (h *History) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.charts = make(map[string]*ring)
+	h.order = nil
+	h.lines = make(map[string]*ring)
+	h.last = nil
+}
+
+// Last returns the latest updates and the time they were added.
+func (h *History) Last() (*WidgetsUpdates, time.Time) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return h.last, h.updated
+}
+
+// Series returns the retained points of the line chart, one list per series.
+func (h *History) Series(id string) [][]LinePoint {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	r, ok := h.charts[id]
+	if !ok {
+		return nil
+	}
+	return r.Series()
+}
+
+// Snapshot returns the retained history of all line charts together with
+// the latest values of the other widgets, the firing alerts and the health
+// of the services. The latest values of stats and histograms come with the
+// retained points of their sparklines and percentiles.
+func (h *History) Snapshot() *WidgetsUpdates {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	s := &WidgetsUpdates{
+		Gauges:     []*GaugeUpdate{},
+		LineCharts: []*LineChartUpdate{},
+		Texts:      []*TextUpdate{},
+		History:    []*LineChartHistory{},
+	}
+
+	if h.last != nil {
+		s.Gauges = h.last.Gauges
+		s.Texts = h.last.Texts
+		s.Alerts = h.last.Alerts
+		s.Statuses = h.last.Statuses
+		s.Health = h.last.Health
+		s.GCPauses = h.last.GCPauses
+		s.Tables = h.last.Tables
+		s.BarCharts = h.last.BarCharts
+
+		for _, hg := range h.last.Histograms {
+			u := *hg
+			if r, ok := h.lines[hg.ID]; ok {
+				u.History = r.Series()
+			}
+			s.Histograms = append(s.Histograms, &u)
+		}
+		for _, sv := range h.last.Stats {
+			u := *sv
+			if r, ok := h.lines[sv.ID]; ok {
+				u.History = r.Series()[0]
+			}
+			s.Stats = append(s.Stats, &u)
+		}
+	}
+
+	for _, id := range h.order {
+		s.History = append(s.History, &LineChartHistory{
+			ID:     id,
+			Series: h.charts[id].Series(),
+			Labels: h.charts[id].labels,
+		})
+	}
+
+	return s
+}
+
+type ring struct {
+	ticks  [][]LinePoint
+	start  int
+	count  int
+	labels []string
+}
+
+func newRing(size int) *ring {
+	return &ring{
+		ticks: make([][]LinePoint, size),
+	}
+}
+
+func (r *ring) Push(points []LinePoint) {
+	end := (r.start + r.count) % len(r.ticks)
+	r.ticks[end] = points
+	if r.count < len(r.ticks) {
+		r.count++
+	} else {
+		r.start = (r.start + 1) % len(r.ticks)
+	}
+}
+
+// Series transposes the retained ticks into one list of points per series.
+// A series that appeared later, like those of wildcard metrics, is padded
+// with missing values, so that all series are aligned.
+func (r *ring) Series() [][]LinePoint {
+	series := [][]LinePoint{}
+	for i := 0; i < r.count; i++ {
+		tick := r.ticks[(r.start+i)%len(r.ticks)]
+		for s, p := range tick {
+			if s == len(series) {
+				padding := []LinePoint{}
+				if s > 0 {
+					for _, prev := range series[0][:len(series[0])-1] {
+						padding = append(padding, LinePoint{Time: prev.Time})
+					}
+				}
+				series = append(series, padding)
+			}
+			series[s] = append(series[s], p)
+		}
+	}
+	return series
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func lineChartUpdates(id string, ts ...int64) []*WidgetsUpdates {
+	updates := []*WidgetsUpdates{}
+	for _, t := range ts {
+		updates = append(updates, &WidgetsUpdates{
+			Gauges: []*GaugeUpdate{
+				{ID: "g1", Value: floatPtr(float64(t) / 10)},
+			},
+			LineCharts: []*LineChartUpdate{
+				{
+					ID: id,
+					Points: []LinePoint{
+						{Time: t, Y: floatPtr(float64(t * 10))},
+						{Time: t, Y: floatPtr(float64(t * 100))},
+					},
+				},
+			},
+			Texts: []*TextUpdate{},
+		})
+	}
+	return updates
+}
+
+func TestHistory_Snapshot(t *testing.T) {
+	tests := []struct {
+		name      string
+		retention time.Duration
+		ticks     []int64
+		want      [][]LinePoint
+	}{
+		{
+			name:      "partially filled",
+			retention: 3 * time.Second,
+			ticks:     []int64{1, 2},
+			want: [][]LinePoint{
+				{{Time: 1, Y: floatPtr(10)}, {Time: 2, Y: floatPtr(20)}},
+				{{Time: 1, Y: floatPtr(100)}, {Time: 2, Y: floatPtr(200)}},
+			},
+		},
+		{
+			name:      "wrapped around",
+			retention: 3 * time.Second,
+			ticks:     []int64{1, 2, 3, 4, 5},
+			want: [][]LinePoint{
+				{{Time: 3, Y: floatPtr(30)}, {Time: 4, Y: floatPtr(40)}, {Time: 5, Y: floatPtr(50)}},
+				{{Time: 3, Y: floatPtr(300)}, {Time: 4, Y: floatPtr(400)}, {Time: 5, Y: floatPtr(500)}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHistory(tt.retention, time.Second)
+			for _, u := range lineChartUpdates("lc1", tt.ticks...) {
+				h.Add(u)
+			}
+
+			s := h.Snapshot()
+			assert.Equal(t, []*LineChartHistory{{ID: "lc1", Series: tt.want}}, s.History)
+			assert.Equal(t, []*GaugeUpdate{{ID: "g1", Value: floatPtr(float64(tt.ticks[len(tt.ticks)-1]) / 10)}}, s.Gauges)
+			assert.Empty(t, s.LineCharts)
+		})
+	}
+}
+
+func TestHistory_Snapshot_NewSeries(t *testing.T) {
+	h := NewHistory(time.Minute, time.Second)
+	h.Add(&WidgetsUpdates{LineCharts: []*LineChartUpdate{
+		{ID: "lc1", Series: []string{"a"}, Points: []LinePoint{{Time: 1, Y: floatPtr(1)}}},
+	}})
+	h.Add(&WidgetsUpdates{LineCharts: []*LineChartUpdate{
+		{ID: "lc1", Series: []string{"a", "b"}, Points: []LinePoint{{Time: 2, Y: floatPtr(2)}, {Time: 2, Y: floatPtr(3)}}},
+	}})
+
+	assert.Equal(t, []*LineChartHistory{
+		{
+			ID: "lc1",
+			Series: [][]LinePoint{
+				{{Time: 1, Y: floatPtr(1)}, {Time: 2, Y: floatPtr(2)}},
+				{{Time: 1, Y: nil}, {Time: 2, Y: floatPtr(3)}},
+			},
+			Labels: []string{"a", "b"},
+		},
+	}, h.Snapshot().History)
+}
+
+func TestHistory_Snapshot_Latest(t *testing.T) {
+	u := &WidgetsUpdates{
+		GCPauses:  []*GCPausesUpdate{{ID: "gc1"}},
+		Tables:    []*TableUpdate{{ID: "tb1"}},
+		BarCharts: []*BarChartUpdate{{ID: "bc1"}},
+	}
+
+	h := NewHistory(time.Minute, time.Second)
+	h.Add(u)
+
+	s := h.Snapshot()
+	assert.Equal(t, u.GCPauses, s.GCPauses)
+	assert.Equal(t, u.Tables, s.Tables)
+	assert.Equal(t, u.BarCharts, s.BarCharts)
+}
+
+func TestHistory_Snapshot_Histogram(t *testing.T) {
+	h := NewHistory(2*time.Second, time.Second)
+	for i := int64(1); i <= 3; i++ {
+		h.Add(&WidgetsUpdates{Histograms: []*HistogramUpdate{
+			{
+				ID:          "hg1",
+				Buckets:     []*HistogramBucket{{Bound: "10", Count: float64(i)}},
+				Percentiles: []LinePoint{{Time: i, Y: floatPtr(5)}, {Time: i, Y: floatPtr(float64(i))}},
+			},
+		}})
+	}
+
+	assert.Equal(t, []*HistogramUpdate{
+		{
+			ID:          "hg1",
+			Buckets:     []*HistogramBucket{{Bound: "10", Count: 3}},
+			Percentiles: []LinePoint{{Time: 3, Y: floatPtr(5)}, {Time: 3, Y: floatPtr(3)}},
+			History: [][]LinePoint{
+				{{Time: 2, Y: floatPtr(5)}, {Time: 3, Y: floatPtr(5)}},
+				{{Time: 2, Y: floatPtr(2)}, {Time: 3, Y: floatPtr(3)}},
+			},
+		},
+	}, h.Snapshot().Histograms)
+	assert.Empty(t, h.Snapshot().History)
+
+	h.Rekey(map[string]string{"hg1": "hg2"})
+	h.Add(&WidgetsUpdates{Histograms: []*HistogramUpdate{{ID: "hg2", Percentiles: []LinePoint{{Time: 4, Y: floatPtr(5)}, {Time: 4, Y: floatPtr(4)}}}}})
+	assert.Equal(t, [][]LinePoint{
+		{{Time: 3, Y: floatPtr(5)}, {Time: 4, Y: floatPtr(5)}},
+		{{Time: 3, Y: floatPtr(3)}, {Time: 4, Y: floatPtr(4)}},
+	}, h.Snapshot().Histograms[0].History)
+}
+
+func TestHistory_Snapshot_Stat(t *testing.T) {
+	h := NewHistory(2*time.Second, time.Second)
+	for i := int64(1); i <= 3; i++ {
+		h.Add(&WidgetsUpdates{Stats: []*StatUpdate{
+			{ID: "sv1", Point: LinePoint{Time: i, Y: floatPtr(float64(i))}, Text: stringPtr("1"), Level: "warning"},
+		}})
+	}
+
+	assert.Equal(t, []*StatUpdate{
+		{
+			ID:      "sv1",
+			Point:   LinePoint{Time: 3, Y: floatPtr(3)},
+			Text:    stringPtr("1"),
+			Level:   "warning",
+			History: []LinePoint{{Time: 2, Y: floatPtr(2)}, {Time: 3, Y: floatPtr(3)}},
+		},
+	}, h.Snapshot().Stats)
+}
+
+func TestHistory_Snapshot_Disabled(t *testing.T) {
+	h := NewHistory(0, time.Second)
+	for _, u := range lineChartUpdates("lc1", 1, 2) {
+		h.Add(u)
+	}
+
+	s := h.Snapshot()
+	assert.Empty(t, s.History)
+	assert.Equal(t, []*GaugeUpdate{{ID: "g1", Value: floatPtr(0.2)}}, s.Gauges)
+}
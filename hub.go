@@ -1,24 +1,43 @@
 package main
 
-import "github.com/gorilla/websocket"
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gorilla/websocket"
+)
 
 type Client struct {
-	hub    *Hub
-	conn   *websocket.Conn
-	dataCh chan []byte
+	hub       *Hub
+	conn      *websocket.Conn
+	dashboard *Dashboard
+	dataCh    chan []byte
+}
+
+func (c *Client) Receives(m *Message) bool {
+	return m.Dashboard == "" || c.dashboard != nil && c.dashboard.Name == m.Dashboard
+}
+
+// Message is delivered to the clients viewing the dashboard, or to all
+// clients if no dashboard is given.
+type Message struct {
+	Dashboard string
+	Data      []byte
 }
 
 type Hub struct {
 	clients map[*Client]struct{}
-	dataCh  chan []byte
+	history *History
+	dataCh  chan *Message
 	enterCh chan *Client
 	leaveCh chan *Client
 }
 
-func NewHub() *Hub {
+func NewHub(history *History) *Hub {
 	return &Hub{
 		clients: make(map[*Client]struct{}),
-		dataCh:  make(chan []byte),
+		history: history,
+		dataCh:  make(chan *Message),
 		enterCh: make(chan *Client),
 		leaveCh: make(chan *Client),
 	}
@@ -29,6 +48,7 @@ func (h *Hub) Start() {
 		select {
 		case client := <-h.enterCh:
 			h.clients[client] = struct{}{}
+			h.backfill(client)
 		case client := <-h.leaveCh:
 			if _, ok := h.clients[client]; ok {
 				delete(h.clients, client)
@@ -36,8 +56,12 @@ func (h *Hub) Start() {
 			}
 		case message := <-h.dataCh:
 			for client := range h.clients {
+				if !client.Receives(message) {
+					continue
+				}
+
 				select {
-				case client.dataCh <- message:
+				case client.dataCh <- message.Data:
 				default:
 					close(client.dataCh)
 					delete(h.clients, client)
@@ -46,3 +70,27 @@ func (h *Hub) Start() {
 		}
 	}
 }
+
+// backfill sends the retained history of the client's dashboard to a client
+// that has just joined, before it starts receiving live updates.
+func (h *Hub) backfill(client *Client) {
+	if h.history == nil {
+		return
+	}
+
+	var has func(id string) bool
+	if client.dashboard != nil {
+		has = client.dashboard.Has
+	}
+
+	data, err := json.Marshal(h.history.Snapshot().Filter(has))
+	if err != nil {
+		fmt.Println("Error serializing history:", err)
+		return
+	}
+
+	select {
+	case client.dataCh <- data:
+	default:
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func receive(c *Client) string {
+	select {
+	case data := <-c.dataCh:
+		return string(data)
+	case <-time.After(100 * time.Millisecond):
+		return ""
+	}
+}
+
+func TestHub_Routing(t *testing.T) {
+	dashboardA := &Dashboard{Name: "a", ids: map[string]struct{}{"c1": {}}}
+	dashboardB := &Dashboard{Name: "b", ids: map[string]struct{}{"c2": {}}}
+
+	history := NewHistory(time.Minute, time.Second)
+	history.Add(&WidgetsUpdates{
+		Gauges:     []*GaugeUpdate{{ID: "c1", Value: floatPtr(0.5)}, {ID: "c2", Value: floatPtr(0.7)}},
+		LineCharts: []*LineChartUpdate{},
+		Texts:      []*TextUpdate{},
+	})
+
+	hub := NewHub(history)
+	go hub.Start()
+
+	clientA := &Client{hub: hub, dashboard: dashboardA, dataCh: make(chan []byte, 10)}
+	clientB := &Client{hub: hub, dashboard: dashboardB, dataCh: make(chan []byte, 10)}
+
+	hub.enterCh <- clientA
+	hub.enterCh <- clientB
+
+	assert.Equal(t, `{"g":[{"i":"c1","v":0.5}],"lc":[],"t":[]}`, receive(clientA))
+	assert.Equal(t, `{"g":[{"i":"c2","v":0.7}],"lc":[],"t":[]}`, receive(clientB))
+
+	hub.dataCh <- &Message{Dashboard: "a", Data: []byte("for a")}
+	hub.dataCh <- &Message{Data: []byte("for all")}
+
+	assert.Equal(t, "for a", receive(clientA))
+	assert.Equal(t, "for all", receive(clientA))
+	assert.Equal(t, "for all", receive(clientB))
+	assert.Equal(t, "", receive(clientB))
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/antonholmquist/jason"
+)
+
+// Sizes of the widgets of a generated configuration.
+const (
+	initTextSize      = 4
+	initLineChartSize = 6
+)
+
+// variable is a variable found in the crawled services.
+type variable struct {
+	path     string
+	numeric  bool
+	services []string
+}
+
+// GenerateConf builds a starter configuration from the crawled variables of
+// the services: a LineChart for every number, and a Text for every string,
+// boolean and array of strings, packed into rows.
+func GenerateConf(services []RawService, vars []*Expvars) *RawConfig {
+	found := map[string]*variable{}
+	for i, v := range vars {
+		name := services[i].Name
+		v.Walk(func(path string, value *jason.Value) {
+			if _, ok := metricName(path); !ok {
+				return
+			}
+
+			numeric := false
+			if _, err := value.Number(); err == nil {
+				numeric = true
+			} else if !isText(value) {
+				return
+			}
+
+			if found[path] == nil {
+				found[path] = &variable{path: path, numeric: numeric}
+			}
+			found[path].services = append(found[path].services, name)
+		})
+	}
+
+	paths := []string{}
+	for path := range found {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	texts, charts := []RawItem{}, []RawItem{}
+	for _, path := range paths {
+		v := found[path]
+		sort.Strings(v.services)
+		metric, _ := metricName(path)
+
+		if v.numeric {
+			conf := map[string]interface{}{"metric": metric}
+			if len(v.services) < len(services) {
+				conf["services"] = v.services
+			}
+			charts = append(charts, newItem(LineChartType, "", initLineChartSize, conf))
+			continue
+		}
+
+		for _, service := range v.services {
+			title := ""
+			if len(services) > 1 {
+				title = fmt.Sprintf("%s of %s", path, service)
+			}
+			conf := map[string]interface{}{"metric": metric, "service": service}
+			texts = append(texts, newItem(TextType, title, initTextSize, conf))
+		}
+	}
+
+	return &RawConfig{
+		Services: services,
+		Rows:     packRows(append(texts, charts...)),
+	}
+}
+
+// metricName returns the metric that reads the variable at the path. Paths
+// that would be read as expressions are quoted, and those that can't be
+// quoted are not usable at all.
+func metricName(path string) (string, bool) {
+	if strings.Contains(path, "'") {
+		return "", false
+	}
+	if strings.ContainsAny(path, "()") || strings.IndexFunc(path, unicode.IsSpace) >= 0 || pathOperator(path) >= 0 {
+		return "'" + path + "'", true
+	}
+	return path, true
+}
+
+// isText tells whether the value can be shown by a Text widget.
+func isText(value *jason.Value) bool {
+	if _, err := value.String(); err == nil {
+		return true
+	}
+	if _, err := value.Boolean(); err == nil {
+		return true
+	}
+	items, err := value.Array()
+	if err != nil || len(items) == 0 {
+		return false
+	}
+	for _, item := range items {
+		if _, err := item.String(); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func newItem(kind, title string, size int, conf map[string]interface{}) RawItem {
+	data, _ := json.Marshal(conf)
+	raw := json.RawMessage(data)
+	return RawItem{
+		Type:  kind,
+		Title: title,
+		Size:  size,
+		Conf:  &raw,
+	}
+}
+
+// packRows fills the rows with the items in order, starting a new row when
+// the next item doesn't fit.
+func packRows(items []RawItem) []RawRow {
+	rows := []RawRow{}
+	width := RowWidth
+	for _, item := range items {
+		if width+item.Size > RowWidth {
+			rows = append(rows, RawRow{})
+			width = 0
+		}
+		last := &rows[len(rows)-1]
+		last.Items = append(last.Items, item)
+		width += item.Size
+	}
+	return rows
+}
+
+// serviceName names the service after the host and the port of its URL.
+func serviceName(rawurl string, taken map[string]bool) string {
+	name := rawurl
+	if u, err := ParseURL(rawurl); err == nil && u.Host != "" {
+		name = strings.Replace(u.Host, ":", "-", -1)
+	}
+
+	unique := name
+	for i := 2; taken[unique]; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	taken[unique] = true
+	return unique
+}
+
+// Init runs the init command and returns its exit code.
+func Init(args []string) int {
+	flags := flag.NewFlagSet("init", flag.ExitOnError)
+	output := flags.String("o", "", "File to write the configuration to, instead of the standard output")
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s init [-o dashboard.json] <service url>...\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+
+	services := []RawService{}
+	vars := []*Expvars{}
+	taken := map[string]bool{}
+	for _, rawurl := range flags.Args() {
+		raw := RawService{
+			Name: serviceName(rawurl, taken),
+			URL:  rawurl,
+		}
+
+		service, err := ReadService(raw)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Invalid service:", err)
+			return 1
+		}
+		v, err := service.Fetcher.Fetch(service.URL)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not crawl %s: %s\n", rawurl, err)
+			return 1
+		}
+
+		services = append(services, raw)
+		vars = append(vars, v)
+	}
+
+	data, err := json.MarshalIndent(GenerateConf(services, vars), "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Could not generate configuration:", err)
+		return 1
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		os.Stdout.Write(data)
+		return 0
+	}
+	if err := ioutil.WriteFile(*output, data, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, "Could not write configuration:", err)
+		return 1
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", *output)
+	return 0
+}
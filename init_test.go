@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateConf(t *testing.T) {
+	services := []RawService{
+		{Name: "localhost-4004", URL: "localhost:4004"},
+		{Name: "localhost-4005", URL: "localhost:4005"},
+	}
+
+	vars := []*Expvars{}
+	for _, data := range []string{
+		`{"cmdline": ["./service", "-p", "4004"], "memstats": {"Alloc": 1, "HeapAlloc": 2, "PauseNs": [1, 2], "EnableGC": true}, "requests": 3, "nothing": null}`,
+		`{"cmdline": ["./service", "-p", "4005"], "memstats": {"Alloc": 1, "HeapAlloc": 2, "PauseNs": [1, 2], "EnableGC": true}, "version": "1.2"}`,
+	} {
+		v, err := ParseExpvars(strings.NewReader(data))
+		assert.NoError(t, err)
+		vars = append(vars, v)
+	}
+
+	conf := GenerateConf(services, vars)
+
+	data, err := json.Marshal(conf)
+	assert.NoError(t, err)
+
+	expected := `{"services":[{"name":"localhost-4004","url":"localhost:4004"},{"name":"localhost-4005","url":"localhost:4005"}],"rows":[` +
+		`{"items":[` +
+		`{"type":"Text","title":"cmdline of localhost-4004","size":4,"conf":{"metric":"cmdline","service":"localhost-4004"}},` +
+		`{"type":"Text","title":"cmdline of localhost-4005","size":4,"conf":{"metric":"cmdline","service":"localhost-4005"}},` +
+		`{"type":"Text","title":"memstats.EnableGC of localhost-4004","size":4,"conf":{"metric":"memstats.EnableGC","service":"localhost-4004"}}]},` +
+		`{"items":[` +
+		`{"type":"Text","title":"memstats.EnableGC of localhost-4005","size":4,"conf":{"metric":"memstats.EnableGC","service":"localhost-4005"}},` +
+		`{"type":"Text","title":"version of localhost-4005","size":4,"conf":{"metric":"version","service":"localhost-4005"}}]},` +
+		`{"items":[` +
+		`{"type":"LineChart","size":6,"conf":{"metric":"memstats.Alloc"}},` +
+		`{"type":"LineChart","size":6,"conf":{"metric":"memstats.HeapAlloc"}}]},` +
+		`{"items":[` +
+		`{"type":"LineChart","size":6,"conf":{"metric":"requests","services":["localhost-4004"]}}]}]}`
+	assert.Equal(t, expected, string(data))
+
+	_, err = conf.ParseConf()
+	assert.NoError(t, err)
+}
+
+func TestServiceName(t *testing.T) {
+	taken := map[string]bool{}
+
+	assert.Equal(t, "localhost-4004", serviceName("localhost:4004", taken))
+	assert.Equal(t, "localhost-4004-2", serviceName("http://localhost:4004/debug/vars", taken))
+	assert.Equal(t, "example.com", serviceName("https://example.com/vars", taken))
+}
+
+func TestGenerateConf_ReadsEveryVariable(t *testing.T) {
+	vars, err := ParseExpvars(strings.NewReader(`{
+		"http-requests": 5,
+		"handlers": {"/api/users": {"count": 3}},
+		"routes": {"GET /orders (v2)": 4},
+		"version": "1.2",
+		"owner's": 1
+	}`))
+	assert.NoError(t, err)
+
+	services := []RawService{{Name: "localhost-4004", URL: "localhost:4004"}}
+	conf, err := GenerateConf(services, []*Expvars{vars}).ParseConf()
+	assert.NoError(t, err)
+
+	metrics := []*Metric{}
+	for _, ch := range conf.Widgets.LineCharts {
+		metrics = append(metrics, ch.Metric)
+	}
+	for _, text := range conf.Widgets.Texts {
+		metrics = append(metrics, text.Metric)
+	}
+
+	// the variable whose key has a quote can't be read
+	assert.Len(t, metrics, 4)
+	for _, m := range metrics {
+		assert.NotNil(t, ReadMetric(m, vars), m.String())
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
+	"strings"
 	"time"
 
 	"html/template"
@@ -18,13 +20,28 @@ var Now = time.Now
 var upgrader = websocket.Upgrader{}
 
 var (
-	interval  = flag.Duration("i", 5*time.Second, "Polling interval: 5s, 1m")
-	port      = flag.Int("p", 4444, "Dashboard HTTP port")
-	dashboard = flag.String("d", "", "Dashboard configuration file")
-	fs        = flag.Bool("fs", false, "Serve static files from file system")
+	interval         = flag.Duration("i", 5*time.Second, "Polling interval: 5s, 1m")
+	port             = flag.Int("p", 4444, "Dashboard HTTP port")
+	dashboard        = flag.String("d", "", "Dashboard configuration file")
+	retention        = flag.Duration("r", time.Hour, "History retention window: 30m, 1h")
+	storage          = flag.String("s", "", "Directory to store crawled values in (optional)")
+	storageRetention = flag.Duration("sr", 7*24*time.Hour, "Retention of stored values: 24h, 168h")
+	record           = flag.String("record", "", "File to record crawled variables to (optional)")
+	replay           = flag.String("replay", "", "File to replay recorded variables from (optional)")
+	speed            = flag.Float64("speed", 1, "Replay speed: 1, 10")
+	fs               = flag.Bool("fs", false, "Serve static files from file system")
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "validate":
+			os.Exit(Validate(os.Args[2:]))
+		case "init":
+			os.Exit(Init(os.Args[2:]))
+		}
+	}
+
 	flag.Usage = Usage
 	flag.Parse()
 
@@ -34,6 +51,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	if *retention < 0 {
+		fmt.Fprintln(os.Stderr, "Invalid history retention window.")
+		Usage()
+		os.Exit(1)
+	}
+
+	if *storageRetention <= 0 {
+		fmt.Fprintln(os.Stderr, "Invalid retention of stored values.")
+		Usage()
+		os.Exit(1)
+	}
+
+	if *record != "" && *replay != "" {
+		fmt.Fprintln(os.Stderr, "Can't record and replay at the same time.")
+		Usage()
+		os.Exit(1)
+	}
+
+	if *speed <= 0 {
+		fmt.Fprintln(os.Stderr, "Invalid replay speed.")
+		Usage()
+		os.Exit(1)
+	}
+
 	// Load configuration file
 	conf, err := LoadConf(*dashboard)
 	if err != nil {
@@ -41,41 +82,97 @@ func main() {
 		os.Exit(1)
 	}
 
+	history := NewHistory(*retention, *interval)
+
 	// Start handler for web-socket connections
-	hub := NewHub()
+	hub := NewHub(history)
 	go hub.Start()
 
 	fetcher := NewFetcher()
 
 	crawler := &Crawler{
-		interval: *interval,
-		fetcher:  fetcher,
-		hub:      hub,
-		widgets:  conf.Widgets,
-		services: conf.Services,
+		interval:   *interval,
+		fetcher:    fetcher,
+		hub:        hub,
+		history:    history,
+		widgets:    conf.Widgets,
+		services:   conf.Services,
+		dashboards: conf.Dashboards,
+		alerts:     conf.Alerts,
+		notifier:   conf.Notifier,
+		reloadCh:   make(chan *reload),
+	}
+
+	if *record != "" {
+		crawler.recorder, err = NewRecorder(*record)
+		if err != nil {
+			fmt.Println("Could not open recording:", err)
+			os.Exit(1)
+		}
+	}
+
+	if *replay != "" {
+		crawler.replayer, err = LoadRecording(*replay, *speed)
+		if err != nil {
+			fmt.Println("Could not load recording:", err)
+			os.Exit(1)
+		}
+		crawler.replayer.Start()
+		Now = crawler.replayer.Now
+	}
+
+	if *storage != "" {
+		crawler.storage, err = OpenStorage(*storage, *storageRetention)
+		if err != nil {
+			fmt.Println("Could not open storage:", err)
+			os.Exit(1)
+		}
+		crawler.Restore(Now().Add(-*retention))
 	}
 	go crawler.Start()
 
-	err = ListenAndServe(*port, hub, conf.Layout, *fs)
+	reloader := NewReloader(*dashboard, conf, crawler)
+	go reloader.Start()
+
+	api := &API{
+		reloader: reloader,
+		history:  history,
+		storage:  crawler.storage,
+		crawler:  crawler,
+	}
+
+	err = ListenAndServe(*port, hub, reloader, api, *fs)
 	if err != nil {
 		fmt.Println("Could not start HTTP server:", err)
 		os.Exit(1)
 	}
 }
 
+var templates = []string{"templates/index.html", "templates/dashboards.html", "templates/explore.html"}
+
+// LoadTemplate loads all page templates, each named after its file name.
 func LoadTemplate(fsMode bool) (*template.Template, error) {
 	if fsMode {
-		return template.ParseFiles("templates/index.html")
+		return template.ParseFiles(templates...)
 	} else {
-		data, err := Asset("templates/index.html")
-		if err != nil {
-			return nil, err
+		t := template.New("")
+		for _, name := range templates {
+			data, err := Asset(name)
+			if err != nil {
+				return nil, err
+			}
+
+			_, err = t.New(path.Base(name)).Parse(string(data))
+			if err != nil {
+				return nil, err
+			}
 		}
 
-		return template.New("templates/index.html").Parse(string(data))
+		return t, nil
 	}
 }
-func ListenAndServe(port int, hub *Hub, layout *Layout, fsMode bool) error {
+
+func ListenAndServe(port int, hub *Hub, reloader *Reloader, api *API, fsMode bool) error {
 	t, err := LoadTemplate(fsMode)
 	if err != nil {
 		return err
@@ -85,13 +182,44 @@ func ListenAndServe(port int, hub *Hub, layout *Layout, fsMode bool) error {
 
 	fmt.Printf("Starting HTTP server on localhost%s\n", addr)
 
-	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+	render := func(w http.ResponseWriter, name string, data map[string]interface{}) {
 		w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
-		err := t.Execute(w, map[string]interface{}{"Port": port, "Layout": *layout})
+		data["Port"] = port
+
+		err := t.ExecuteTemplate(w, name, data)
 		if err != nil {
 			fmt.Println("Error rendering response:", err)
 		}
+	}
+
+	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		conf := reloader.Config()
+		if len(conf.Dashboards) == 1 {
+			render(w, "index.html", map[string]interface{}{"Dashboard": conf.Dashboards[0]})
+		} else {
+			render(w, "dashboards.html", map[string]interface{}{"Dashboards": conf.Dashboards})
+		}
+	})
+	http.HandleFunc("/d/", func(w http.ResponseWriter, r *http.Request) {
+		d := reloader.Config().Dashboard(strings.TrimPrefix(r.URL.Path, "/d/"))
+		if d == nil {
+			http.NotFound(w, r)
+			return
+		}
+
+		render(w, "index.html", map[string]interface{}{"Dashboard": d})
+	})
+	http.HandleFunc("/explore", func(w http.ResponseWriter, r *http.Request) {
+		render(w, "explore.html", map[string]interface{}{
+			"Services": serviceNames(reloader.Config()),
+			"Interval": int64(*interval / time.Millisecond),
+		})
 	})
 	if fsMode {
 		http.Handle("/static/", http.StripPrefix("/static/", http.FileServer(http.Dir("./static"))))
@@ -104,6 +232,8 @@ func ListenAndServe(port int, hub *Hub, layout *Layout, fsMode bool) error {
 		}))
 	}
 
+	api.Register(http.DefaultServeMux)
+
 	http.HandleFunc("/updates", func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
@@ -112,9 +242,10 @@ func ListenAndServe(port int, hub *Hub, layout *Layout, fsMode bool) error {
 		}
 
 		client := &Client{
-			hub:    hub,
-			conn:   conn,
-			dataCh: make(chan []byte, 10),
+			hub:       hub,
+			conn:      conn,
+			dashboard: reloader.Config().Dashboard(r.URL.Query().Get("d")),
+			dataCh:    make(chan []byte, 10),
 		}
 
 		defer func() {
@@ -144,7 +275,10 @@ func Usage() {
 Examples:
 	%s -d=dashboard.json
 	%s -d=dashboard.json -i=10s
+	%s -d=dashboard.json -replay=incident.log -speed=10
+	%s validate dashboard.json
+	%s init localhost:8080 > dashboard.json
 
 For more details and docs, see README: http://github.com/propan/expvardash
-`, progname, progname)
+`, progname, progname, progname, progname, progname)
 }
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/antonholmquist/jason"
+)
+
+// ParsePrometheus reads metrics in the Prometheus text exposition format
+// into the same structure expvars have. A sample without labels is stored
+// under its name, while samples with labels are grouped under their name
+// by their label set, e.g. the sample
+//
+//	http_requests_total{method="GET",code="200"} 1027
+//
+// can be found at "http_requests_total.code=200,method=GET". Dots in label
+// values other than bucket bounds and quantiles are replaced with
+// underscores, since dots separate path elements.
+func ParsePrometheus(r io.Reader) (*Expvars, error) {
+	vars := map[string]interface{}{}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, labels, value, err := parsePrometheusSample(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", n, err)
+		}
+		if value == nil {
+			continue
+		}
+
+		if len(labels) == 0 {
+			if group, ok := vars[name].(map[string]interface{}); ok {
+				group[""] = value
+			} else {
+				vars[name] = value
+			}
+			continue
+		}
+
+		group, ok := vars[name].(map[string]interface{})
+		if !ok {
+			group = map[string]interface{}{}
+			if v, ok := vars[name]; ok {
+				group[""] = v
+			}
+			vars[name] = group
+		}
+		group[labels] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := jason.NewObjectFromBytes(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Expvars{object}, nil
+}
+
+// parsePrometheusSample splits a sample line into the metric name, the key
+// of its label set and its value. Values that can't be represented in JSON
+// (NaN and infinities) are returned as nil.
+func parsePrometheusSample(line string) (string, string, interface{}, error) {
+	end := strings.IndexAny(line, "{ \t")
+	if end <= 0 {
+		return "", "", nil, fmt.Errorf("missing value for %q", line)
+	}
+	name, rest := line[:end], line[end:]
+
+	labels := []string{}
+	if strings.HasPrefix(rest, "{") {
+		var err error
+		labels, rest, err = parsePrometheusLabels(rest[1:])
+		if err != nil {
+			return "", "", nil, err
+		}
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", "", nil, fmt.Errorf("missing value for %s", name)
+	}
+
+	sort.Strings(labels)
+	key := strings.Join(labels, ",")
+
+	if v, err := strconv.ParseInt(fields[0], 10, 64); err == nil {
+		return name, key, v, nil
+	}
+
+	v, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("invalid value %q for %s", fields[0], name)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return name, key, nil, nil
+	}
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+		return name, key, int64(v), nil
+	}
+	return name, key, v, nil
+}
+
+// parsePrometheusLabels reads the labels up to the closing brace and returns
+// them as name=value pairs together with the rest of the line.
+func parsePrometheusLabels(s string) ([]string, string, error) {
+	labels := []string{}
+
+	for {
+		s = strings.TrimLeft(s, " \t,")
+		if strings.HasPrefix(s, "}") {
+			return labels, s[1:], nil
+		}
+
+		eq := strings.Index(s, "=")
+		if eq <= 0 || len(s) < eq+2 || s[eq+1] != '"' {
+			return nil, "", fmt.Errorf("invalid labels")
+		}
+		name := strings.TrimSpace(s[:eq])
+
+		var value bytes.Buffer
+		i := eq + 2
+		for ; i < len(s) && s[i] != '"'; i++ {
+			if s[i] == '\\' && i+1 < len(s) {
+				i++
+				switch s[i] {
+				case 'n':
+					value.WriteByte('\n')
+				default:
+					value.WriteByte(s[i])
+				}
+				continue
+			}
+			value.WriteByte(s[i])
+		}
+		if i == len(s) {
+			return nil, "", fmt.Errorf("unterminated value of label %s", name)
+		}
+
+		labels = append(labels, name+"="+prometheusLabelValue(name, value.String()))
+		s = s[i+1:]
+	}
+}
+
+// prometheusLabelValue replaces the dots in the value of a label, since dots
+// separate path elements. The bounds of buckets and quantiles are numbers and
+// are kept as they are, so that histograms can be read as buckets.
+func prometheusLabelValue(name, value string) string {
+	if name == "le" || name == "quantile" {
+		return value
+	}
+	return strings.Replace(value, ".", "_", -1)
+}
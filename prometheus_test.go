@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const prometheusResponse = `# HELP go_goroutines Number of goroutines that currently exist.
+# TYPE go_goroutines gauge
+go_goroutines 42
+# TYPE go_gc_duration_seconds summary
+go_gc_duration_seconds{quantile="0.5"} 4.3e-05
+go_gc_duration_seconds{quantile="1"} 0.000107
+go_gc_duration_seconds_sum 0.0123
+go_gc_duration_seconds_count 17
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{le="0.05"} 24054
+http_request_duration_seconds_bucket{le="0.1"} 33444
+http_request_duration_seconds_bucket{le="+Inf"} 144320
+# TYPE http_requests_total counter
+http_requests_total{method="GET",code="200"} 1027 1395066363000
+http_requests_total{code="500", method="GET"} 3
+http_requests_total{method="POST",code="200",path="/api/v1.2"} 1.5e+03
+process_start_time_seconds NaN
+`
+
+func TestParsePrometheus(t *testing.T) {
+	vars, err := ParsePrometheus(strings.NewReader(prometheusResponse))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		metric string
+		want   interface{}
+	}{
+		{metric: "go_goroutines", want: int64(42)},
+		{metric: "go_gc_duration_seconds.quantile=1", want: 0.000107},
+		{metric: "go_gc_duration_seconds_sum", want: 0.0123},
+		{metric: "http_requests_total.code=200,method=GET", want: int64(1027)},
+		{metric: "http_requests_total.code=500,method=GET", want: int64(3)},
+		{metric: "'http_requests_total.code=200,method=POST,path=/api/v1_2'", want: int64(1500)},
+		{metric: "'http_requests_total.code=200,method=GET' / go_gc_duration_seconds_count", want: 1027.0 / 17},
+		{metric: "process_start_time_seconds", want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.metric, func(t *testing.T) {
+			m, err := NewMetric(tt.metric)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want, ReadMetric(m, vars))
+		})
+	}
+}
+
+func TestParsePrometheus_Buckets(t *testing.T) {
+	vars, err := ParsePrometheus(strings.NewReader(prometheusResponse))
+	assert.NoError(t, err)
+
+	// bounds and quantiles keep their dots
+	quantiles, err := vars.GetObject("go_gc_duration_seconds")
+	assert.NoError(t, err)
+	assert.Contains(t, quantiles.Map(), "quantile=0.5")
+
+	h := &Histogram{Metric: NewSafeMetric("http_request_duration_seconds_bucket")}
+	d := h.Distribution([]string{"service1"}, map[string]*Expvars{"service1": vars})
+	assert.Equal(t, &Distribution{Bounds: []float64{0.05, 0.1, math.Inf(1)}, Counts: []float64{24054, 9390, 110876}}, d)
+}
+
+func TestParsePrometheus_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "missing value",
+			data:    "# comment\ngo_goroutines\n",
+			wantErr: `line 2: missing value for "go_goroutines"`,
+		},
+		{
+			name:    "invalid value",
+			data:    "go_goroutines many\n",
+			wantErr: `line 1: invalid value "many" for go_goroutines`,
+		},
+		{
+			name:    "unterminated label value",
+			data:    `http_requests_total{code="200} 1`,
+			wantErr: `line 1: unterminated value of label code`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParsePrometheus(strings.NewReader(tt.data))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestPrometheusFetcher_Fetch_Success(t *testing.T) {
+	tearUp()
+	defer tearDown()
+
+	mux.HandleFunc("/metrics", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, prometheusResponse)
+	})
+
+	s, err := ReadService(RawService{
+		Name:   "service-1",
+		URL:    server.URL,
+		Format: PrometheusFormat,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "/metrics", s.URL.Path)
+
+	vars, err := s.Fetcher.Fetch(s.URL)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), ReadMetric(NewSafeMetric("go_goroutines"), vars))
+}
+
+func TestReadService_UnknownFormat(t *testing.T) {
+	_, err := ReadService(RawService{
+		Name:   "service-1",
+		URL:    "localhost:4004",
+		Format: "statsd",
+	})
+	assert.EqualError(t, err, "Unknown format of service service-1: statsd")
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Snapshot is a crawl of a service as written to a recording, one JSON
+// document per line.
+type Snapshot struct {
+	Time    time.Time        `json:"t"`
+	Service string           `json:"s"`
+	Vars    *json.RawMessage `json:"v,omitempty"`
+	Error   string           `json:"e,omitempty"`
+
+	vars *Expvars
+}
+
+// Recorder appends every crawl of the services to a recording.
+type Recorder struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewRecorder(path string) (*Recorder, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Recorder{
+		file: f,
+	}, nil
+}
+
+func (r *Recorder) Record(service string, vars *Expvars, err error, t time.Time) error {
+	s := &Snapshot{
+		Time:    t,
+		Service: service,
+	}
+	if err != nil {
+		s.Error = err.Error()
+	} else if vars != nil {
+		data, err := vars.MarshalJSON()
+		if err != nil {
+			return err
+		}
+		raw := json.RawMessage(data)
+		s.Vars = &raw
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, err = r.file.Write(append(data, '\n'))
+	return err
+}
+
+func (r *Recorder) Close() error {
+	return r.file.Close()
+}
+
+// Replayer plays a recording back, at the recorded pace multiplied by the
+// speed. It provides the time of the recording to the rest of the
+// application, and fetchers that return the snapshots of that time.
+type Replayer struct {
+	snapshots map[string][]*Snapshot
+	start     time.Time
+	end       time.Time
+	speed     float64
+	began     time.Time
+	clock     func() time.Time
+}
+
+func LoadRecording(path string, speed float64) (*Replayer, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := &Replayer{
+		snapshots: map[string][]*Snapshot{},
+		speed:     speed,
+		clock:     time.Now,
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var s Snapshot
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+		if s.Vars != nil {
+			s.vars, err = ParseExpvars(bytes.NewReader(*s.Vars))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %s", line, err)
+			}
+		}
+
+		r.snapshots[s.Service] = append(r.snapshots[s.Service], &s)
+		if r.start.IsZero() || s.Time.Before(r.start) {
+			r.start = s.Time
+		}
+		if s.Time.After(r.end) {
+			r.end = s.Time
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(r.snapshots) == 0 {
+		return nil, errors.New("Recording is empty")
+	}
+
+	for _, snapshots := range r.snapshots {
+		sort.Stable(byTime(snapshots))
+	}
+
+	return r, nil
+}
+
+type byTime []*Snapshot
+
+func (s byTime) Len() int           { return len(s) }
+func (s byTime) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byTime) Less(i, j int) bool { return s[i].Time.Before(s[j].Time) }
+
+// Start starts the playback from the beginning of the recording.
+func (r *Replayer) Start() {
+	r.began = r.clock()
+}
+
+// Now is the time of the recording that is being played.
+func (r *Replayer) Now() time.Time {
+	elapsed := float64(r.clock().Sub(r.began)) * r.speed
+	return r.start.Add(time.Duration(elapsed))
+}
+
+// Wall converts a duration of the recording to the time it takes to play.
+func (r *Replayer) Wall(d time.Duration) time.Duration {
+	return time.Duration(float64(d) / r.speed)
+}
+
+// Fetcher returns the fetcher of the recorded crawls of the service.
+func (r *Replayer) Fetcher(service string) Fetcher {
+	return &replayFetcher{
+		replayer: r,
+		service:  service,
+	}
+}
+
+type replayFetcher struct {
+	replayer *Replayer
+	service  string
+}
+
+// Fetch returns the latest snapshot of the service at the time being played.
+func (f *replayFetcher) Fetch(url url.URL) (*Expvars, error) {
+	now := f.replayer.Now()
+	if now.After(f.replayer.end) {
+		return nil, errors.New("Recording has ended")
+	}
+
+	snapshots := f.replayer.snapshots[f.service]
+	i := sort.Search(len(snapshots), func(i int) bool {
+		return snapshots[i].Time.After(now)
+	})
+	if i == 0 {
+		return nil, fmt.Errorf("No recorded crawl of %s yet", f.service)
+	}
+
+	s := snapshots[i-1]
+	if s.Error != "" {
+		return nil, errors.New(s.Error)
+	}
+	return s.vars, nil
+}
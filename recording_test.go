@@ -0,0 +1,95 @@
+package main
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/antonholmquist/jason"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder_Replayer(t *testing.T) {
+	f, err := ioutil.TempFile("", "recording")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	recorder, err := NewRecorder(f.Name())
+	assert.NoError(t, err)
+
+	start := time.Date(2017, 3, 1, 10, 0, 0, 0, time.UTC)
+	for i, data := range []string{`{"goroutines": 10}`, `{"goroutines": 20}`, `{"goroutines": 30}`} {
+		o, err := jason.NewObjectFromBytes([]byte(data))
+		assert.NoError(t, err)
+		assert.NoError(t, recorder.Record("service1", &Expvars{o}, nil, start.Add(time.Duration(i)*10*time.Second)))
+	}
+	assert.NoError(t, recorder.Record("service2", nil, errors.New("connection refused"), start.Add(5*time.Second)))
+	assert.NoError(t, recorder.Close())
+
+	replayer, err := LoadRecording(f.Name(), 10)
+	assert.NoError(t, err)
+
+	clock := time.Unix(1000, 0)
+	replayer.clock = func() time.Time {
+		return clock
+	}
+	replayer.Start()
+
+	service1 := replayer.Fetcher("service1")
+	service2 := replayer.Fetcher("service2")
+
+	tests := []struct {
+		name    string
+		elapsed time.Duration
+		fetcher Fetcher
+		want    int64
+		wantErr string
+	}{
+		{name: "first snapshot", elapsed: 0, fetcher: service1, want: 10},
+		{name: "accelerated", elapsed: 1500 * time.Millisecond, fetcher: service1, want: 20},
+		{name: "no crawl yet", elapsed: 0, fetcher: service2, wantErr: "No recorded crawl of service2 yet"},
+		{name: "recorded error", elapsed: time.Second, fetcher: service2, wantErr: "connection refused"},
+		{name: "last snapshot", elapsed: 2 * time.Second, fetcher: service1, want: 30},
+		{name: "ended", elapsed: 3 * time.Second, fetcher: service1, wantErr: "Recording has ended"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clock = time.Unix(1000, 0).Add(tt.elapsed)
+			assert.Equal(t, start.Add(tt.elapsed*10), replayer.Now())
+
+			vars, err := tt.fetcher.Fetch(url.URL{})
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ReadMetric(NewSafeMetric("goroutines"), vars))
+		})
+	}
+}
+
+func TestCrawler_Tick_Replay(t *testing.T) {
+	crawler := &Crawler{interval: 5 * time.Second}
+	assert.Equal(t, 5*time.Second, crawler.tick())
+
+	crawler.replayer = &Replayer{speed: 10}
+	assert.Equal(t, 500*time.Millisecond, crawler.tick())
+}
+
+func TestLoadRecording_Errors(t *testing.T) {
+	f, err := ioutil.TempFile("", "recording")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	_, err = LoadRecording(f.Name(), 1)
+	assert.EqualError(t, err, "Recording is empty")
+
+	writeConf(t, f.Name(), `{"t": "2017-03-01T10:00:00Z", "s": "service1", "v": {}}`+"\n"+`{"t": `)
+	_, err = LoadRecording(f.Name(), 1)
+	assert.EqualError(t, err, "line 2: unexpected end of JSON input")
+}
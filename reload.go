@@ -0,0 +1,201 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// ScratchDashboard is the name of the dashboard that holds the widgets
+// pinned from the explore page.
+const ScratchDashboard = "scratch"
+
+// Reloader reloads the dashboard configuration on SIGHUP and whenever the
+// configuration file changes. An invalid configuration is rejected and the
+// current one keeps running. The widgets pinned to the scratch dashboard are
+// kept in memory and survive reloads.
+type Reloader struct {
+	path     string
+	interval time.Duration
+	crawler  *Crawler
+	mu       sync.RWMutex
+	conf     *Config
+	modTime  time.Time
+
+	// swap is held while a configuration is loaded and handed to the
+	// crawler, so that the crawler always runs the latest one
+	swap    sync.Mutex
+	raw     *RawConfig
+	scratch []RawItem
+}
+
+func NewReloader(path string, conf *Config, crawler *Crawler) *Reloader {
+	r := &Reloader{
+		path:     path,
+		interval: 2 * time.Second,
+		crawler:  crawler,
+		conf:     conf,
+	}
+	if modTime, err := confModTime(path); err == nil {
+		r.modTime = modTime
+	}
+	if raw, err := ReadConf(path); err == nil {
+		r.raw = raw
+	}
+	return r
+}
+
+func (r *Reloader) Config() *Config {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.conf
+}
+
+func (r *Reloader) Start() {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	for {
+		select {
+		case <-hup:
+			fmt.Println("Received SIGHUP, reloading dashboard configuration")
+			r.Reload()
+		case <-time.After(r.interval):
+			if r.changed() {
+				fmt.Println("Dashboard configuration has changed, reloading")
+				r.Reload()
+			}
+		}
+	}
+}
+
+func (r *Reloader) changed() bool {
+	modTime, err := confModTime(r.path)
+	if err != nil {
+		return false
+	}
+
+	if modTime.Equal(r.modTime) {
+		return false
+	}
+	r.modTime = modTime
+	return true
+}
+
+// confModTime returns the modification time of the configuration file, or
+// the latest one of a configuration directory and the files in it.
+func confModTime(path string) (time.Time, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	modTime := info.ModTime()
+	if !info.IsDir() {
+		return modTime, nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(path, "*.json"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	for _, file := range files {
+		if info, err := os.Stat(file); err == nil && info.ModTime().After(modTime) {
+			modTime = info.ModTime()
+		}
+	}
+	return modTime, nil
+}
+
+func (r *Reloader) Reload() error {
+	r.swap.Lock()
+	defer r.swap.Unlock()
+
+	raw, err := ReadConf(r.path)
+	if err == nil {
+		var conf *Config
+		conf, err = withScratch(raw, r.scratch)
+		if err == nil {
+			r.raw = raw
+			r.set(conf)
+			r.crawler.Reload(conf)
+			return nil
+		}
+	}
+
+	fmt.Println("Could not reload dashboard configuration:", err)
+	return err
+}
+
+// Pin adds the widget to the scratch dashboard.
+func (r *Reloader) Pin(item RawItem) error {
+	r.swap.Lock()
+	defer r.swap.Unlock()
+
+	return r.setScratch(append(r.scratch[:len(r.scratch):len(r.scratch)], item))
+}
+
+// ClearScratch removes all widgets from the scratch dashboard.
+func (r *Reloader) ClearScratch() error {
+	r.swap.Lock()
+	defer r.swap.Unlock()
+
+	return r.setScratch(nil)
+}
+
+// setScratch replaces the widgets of the scratch dashboard. The rest of the
+// configuration is the one last read, so the other widgets keep their IDs,
+// and the running ones are kept along with their state.
+func (r *Reloader) setScratch(scratch []RawItem) error {
+	raw := r.raw
+	if raw == nil {
+		var err error
+		if raw, err = ReadConf(r.path); err != nil {
+			return err
+		}
+	}
+
+	conf, err := withScratch(raw, scratch)
+	if err != nil {
+		return err
+	}
+	conf.Widgets = conf.Widgets.Keep(r.Config().Widgets)
+
+	r.raw, r.scratch = raw, scratch
+	r.set(conf)
+	r.crawler.ReloadDashboard(conf, ScratchDashboard)
+
+	return nil
+}
+
+func (r *Reloader) set(conf *Config) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.conf = conf
+}
+
+// withScratch parses the configuration with the scratch dashboard added to
+// it.
+func withScratch(raw *RawConfig, scratch []RawItem) (*Config, error) {
+	if len(scratch) == 0 {
+		return raw.ParseConf()
+	}
+	if err := raw.checkReserved(); err != nil {
+		return nil, err
+	}
+
+	copied := *raw
+	copied.Dashboards = append(raw.Dashboards[:len(raw.Dashboards):len(raw.Dashboards)], RawDashboard{
+		Name:  ScratchDashboard,
+		Title: "Scratch",
+		Rows:  packRows(scratch),
+	})
+
+	return copied.parseConf()
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const reloadConf = `{
+	"services": [{"name": "service1", "url": "localhost:4004"}],
+	"rows": [{"items": [{"type": "Text", "size": 2, "conf": {"service": "service1", "metric": "%s"}}]}]
+}`
+
+func writeConf(t *testing.T, path string, data string) {
+	err := ioutil.WriteFile(path, []byte(data), 0644)
+	assert.NoError(t, err)
+}
+
+func TestReloader_Reload(t *testing.T) {
+	f, err := ioutil.TempFile("", "dashboard")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	writeConf(t, f.Name(), strings.Replace(reloadConf, "%s", "memstats.Alloc", 1))
+	conf, err := LoadConf(f.Name())
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		interval: time.Hour,
+		hub: &Hub{
+			dataCh: make(chan *Message, 1),
+		},
+		history:  NewHistory(time.Minute, time.Second),
+		services: conf.Services,
+		widgets:  conf.Widgets,
+		reloadCh: make(chan *reload),
+		done:     make(chan struct{}, 1),
+	}
+	crawler.history.Add(lineChartUpdates("c1", 1)[0])
+
+	go crawler.Start()
+	defer crawler.Stop()
+
+	r := NewReloader(f.Name(), conf, crawler)
+
+	// an invalid configuration keeps the current one running
+	writeConf(t, f.Name(), `{"rows": [{"items": [{"type": "Pie", "conf": {}}]}]}`)
+	assert.EqualError(t, r.Reload(), "Unknown widget type: Pie")
+	assert.Equal(t, conf, r.Config())
+
+	writeConf(t, f.Name(), strings.Replace(reloadConf, "%s", "memstats.HeapAlloc", 1))
+	assert.NoError(t, r.Reload())
+	assert.Equal(t, "memstats.HeapAlloc", r.Config().Widgets.Texts[0].Metric.String())
+
+	select {
+	case message := <-crawler.hub.dataCh:
+		assert.Equal(t, `{"reload":true}`, string(message.Data))
+	case <-time.After(time.Second):
+		t.Fatal("Did not get reload notification in time")
+	}
+
+	assert.Equal(t, r.Config().Widgets, crawler.widgets)
+	assert.Empty(t, crawler.history.Snapshot().History)
+}
+
+func TestReloader_Pin(t *testing.T) {
+	f, err := ioutil.TempFile("", "dashboard")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	writeConf(t, f.Name(), strings.Replace(reloadConf, "%s", "memstats.Alloc", 1))
+	conf, err := LoadConf(f.Name())
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		reloadCh: make(chan *reload, 3),
+	}
+	r := NewReloader(f.Name(), conf, crawler)
+
+	item, err := pinItem(apiPin{Type: LineChartType, Service: "service1", Metric: "memstats.HeapAlloc"})
+	assert.NoError(t, err)
+	assert.NoError(t, r.Pin(item))
+
+	d := r.Config().Dashboard(ScratchDashboard)
+	if assert.NotNil(t, d) {
+		assert.Equal(t, "memstats.HeapAlloc of service1", d.Layout.Rows[0].Cols[0].Title)
+	}
+	// the running widgets are kept, the file isn't read again
+	writeConf(t, f.Name(), strings.Replace(reloadConf, "%s", "memstats.Sys", 1))
+	assert.True(t, conf.Widgets.Texts[0] == r.Config().Widgets.Texts[0])
+	assert.Equal(t, ScratchDashboard, (<-crawler.reloadCh).dashboard)
+
+	// pinned widgets survive reloads of the configuration
+	assert.NoError(t, r.Reload())
+	assert.NotNil(t, r.Config().Dashboard(ScratchDashboard))
+	assert.Equal(t, "memstats.Sys", r.Config().Widgets.Texts[0].Metric.String())
+	assert.Equal(t, "", (<-crawler.reloadCh).dashboard)
+
+	// a dashboard of the configuration can't take the place of the pinned one
+	writeConf(t, f.Name(), `{"dashboards": [{"name": "scratch"}]}`)
+	assert.EqualError(t, r.Reload(), "Reserved dashboard name: scratch holds the pinned widgets")
+	assert.Equal(t, "memstats.Sys", r.Config().Widgets.Texts[0].Metric.String())
+
+	assert.NoError(t, r.ClearScratch())
+	assert.Nil(t, r.Config().Dashboard(ScratchDashboard))
+	assert.Len(t, r.Config().Dashboards, 1)
+	assert.Equal(t, ScratchDashboard, (<-crawler.reloadCh).dashboard)
+}
+
+func TestReloader_Changed(t *testing.T) {
+	f, err := ioutil.TempFile("", "dashboard")
+	assert.NoError(t, err)
+	f.Close()
+	defer os.Remove(f.Name())
+
+	r := NewReloader(f.Name(), &Config{}, nil)
+	assert.False(t, r.changed())
+
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(f.Name(), later, later))
+	assert.True(t, r.changed())
+	assert.False(t, r.changed())
+}
+
+func TestReloader_Changed_Directory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dashboards")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "dashboard.json")
+	writeConf(t, file, reloadConf)
+
+	r := NewReloader(dir, &Config{}, nil)
+	assert.False(t, r.changed())
+
+	later := time.Now().Add(time.Minute)
+	assert.NoError(t, os.Chtimes(file, later, later))
+	assert.True(t, r.changed())
+	assert.False(t, r.changed())
+}
@@ -1,5 +1,7 @@
 // Code generated by go-bindata.
 // sources:
+// templates/dashboards.html
+// templates/explore.html
 // templates/index.html
 // static/css/dashboard.css
 // static/css/epoch.min.css
@@ -75,7 +77,47 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _templatesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xcc\x57\xdd\x6f\xa4\x36\x10\x7f\xbf\xbf\x62\x8e\x46\x82\x28\xc1\x6c\x9a\x6b\x55\x6d\x60\xfb\x90\xa4\x52\xab\xaa\x39\x5d\xd2\x56\x55\x94\x07\xaf\x99\x05\xe7\x0c\xa6\xb6\xf7\xab\x88\xff\xbd\x32\xb0\x97\xcd\x2e\x1f\x6d\x75\xd2\xd5\x2f\x0b\xf8\xf7\xe1\x99\xb1\x19\x36\x7c\x7b\x73\x77\xfd\xf0\xc7\xfb\x5b\x48\x4d\x26\x66\x6f\x42\xfb\x03\x82\xe6\x49\xe4\x60\xee\xcc\xde\x00\x00\x84\x29\xd2\xb8\xb9\xac\x6f\x33\x34\x14\x58\x4a\x95\x46\x13\x39\xbf\x3e\xfc\xe0\x7f\xe7\xec\x4d\x1b\x6e\x04\xce\x6e\x37\xc5\x6f\x54\xc1\x0d\xd5\xe9\x5c\x52\x15\x87\x41\xf3\xfc\x05\x27\x78\xfe\x11\x52\x85\x8b\xc8\x49\x8d\x29\xf4\x34\x08\x16\x32\x37\x9a\x24\x52\x26\x02\x69\xc1\x35\x61\x32\x0b\x98\xd6\xdf\x2f\x68\xc6\xc5\x36\xfa\x20\xe7\xd2\xc8\xe9\xbb\xc9\xe4\xfc\x72\x32\x39\xff\x66\x32\x71\x40\xa1\x88\x1c\x6d\xb6\x02\x75\x8a\x68\x1c\x30\xdb\x02\x23\xc7\xe0\xc6\x58\xea\xfe\xca\x34\x53\xbc\x30\xa0\x15\xb3\x0c\x6a\x38\x0b\x9e\x75\xf0\xfc\xe7\x12\xd5\xd6\xbf\x24\x17\xe4\x82\x64\x3c\x27\xcf\xda\x99\x85\x41\x03\x1e\x65\xc7\x97\xff\x9e\x83\x85\x64\xe9\x10\xad\x4e\xcd\x51\x60\x4d\xae\x5a\x19\xa6\x75\xb0\x10\xb8\x99\xcb\x4d\xa2\x78\x5c\xab\xd9\x70\x07\xc2\xef\x56\x3d\xc0\x1f\xbb\xbc\xac\xf6\xf3\xe8\xc5\xbb\x2d\xb1\xa7\x17\x06\x2f\x7b\x2c\x9c\xcb\x78\xbb\x67\x13\xf3\x15\x30\x41\xb5\x8e\x1c\x26\x73\x43\x79\x8e\x6a\x6f\x19\x76\x94\x25\x28\x9a\x27\x08\x27\x3c\x8f\x71\x73\x0e\x27\x4a\xae\x61\x1a\x01\xf9\x99\x6e\xe5\xd2\x90\x0f\x72\xad\xa1\xaa\x5e\x91\xf6\x85\x95\x5c\x1f\x48\x76\xcb\x32\x29\xac\xac\x95\x27\xd7\x52\x1c\x69\x1e\x2f\x58\xf8\x1b\xed\x5f\x7c\x0d\xf6\x4a\x67\xfe\xb7\xf5\x45\x16\xfb\xef\xea\x0b\x91\xf8\x65\x69\x55\xc9\x3d\xff\x0b\xab\xaa\x63\x11\x87\x92\x73\xb9\xe9\x41\x1d\x22\xeb\x03\xe7\xcc\x5a\x83\x07\x7b\x57\x55\x61\x10\xf3\xd5\x08\x9f\xc7\x91\xd3\xb2\x7e\xbc\xa9\x2a\x67\x27\xb8\xe6\x71\x82\xc6\xee\xd8\x71\x8d\x96\x22\x30\xc1\x3c\x1e\x58\x70\x77\x9e\x73\x9a\x61\x9d\xe8\x3a\x35\xa8\x38\x76\xa6\x7a\xd8\xd5\xe7\x06\xb3\x11\xeb\x1e\xe6\x5c\x6e\x80\x51\x83\x89\x54\x5b\xbf\x2c\xdb\x85\x81\x2d\xd0\x48\xf0\x03\xa2\x36\x28\x5b\x8e\x36\xbc\xd1\x5a\xd4\x32\xe3\x90\xb2\x04\xcc\xe3\xa1\xf4\x0c\x88\xf4\x4c\xf5\x3c\xee\xb6\xea\x00\x1f\x03\x0f\x40\xe1\xe1\x3b\xcf\x8e\x15\x55\xd0\xec\x31\x0d\x11\x94\xd5\xd5\xf1\xac\x9d\xc8\x71\x0d\xbf\xe3\xfc\x5e\xb2\x8f\x68\x3c\x67\x6d\x3b\x87\x90\x8c\x8a\x54\x6a\x33\x2d\x4b\x20\xef\xa5\x32\x50\x55\xc1\xb2\x88\xa9\x41\xed\x9c\xbe\x56\x5a\x6b\x22\xf3\x0c\xb5\xa6\x09\x42\x04\x8b\x65\xce\x0c\x97\xb9\x87\xa7\x50\x1e\xc5\x6c\x6d\x5b\x1d\x88\xe0\xa7\xfb\xbb\x5f\x48\x61\x5b\x9f\x87\x24\xa6\x86\x1e\x48\xdb\xd1\xa2\x89\x60\x64\x21\xd5\x2d\x65\xa9\xf7\xc9\xa2\x99\xeb\xf2\xd9\x79\x31\x88\x76\x49\x78\x6c\xd0\x84\x3f\x1d\x9b\xd8\xc1\x17\xe0\xbd\x65\x7d\x62\x3b\x41\xdd\x1c\x9f\x08\x1e\x7b\x64\xec\x58\x48\x05\x1e\x87\x08\x26\x57\xc0\x21\x6c\x63\x20\x05\x11\x98\x27\x26\xbd\x02\x7e\x76\x36\x64\x64\x47\x63\x44\x8a\xa5\x4e\xbd\x61\xa4\x1d\x82\xce\x51\x4c\xc1\x6d\x4f\xb7\x0b\x67\xc0\xcf\x47\x59\x2b\x2a\x96\xa8\xa7\xf0\xf8\x34\x08\xad\x3a\xca\xf2\x69\xae\x77\xc6\xa6\xfe\xc4\x73\xbf\x72\xcf\x76\x89\x3f\x25\x34\x8e\xaf\xed\x29\xf6\xdc\xba\x0f\x82\xe0\x39\xfa\xf6\xe3\xc7\xb8\xa7\xa4\x7e\x34\x12\xab\xed\x87\x53\x70\x0d\xcf\x90\x58\xb2\x3b\x1c\x24\xdd\xd4\xe1\xb9\x02\x17\xc6\x3d\x07\x77\x2e\x8d\x91\x99\xfb\x34\xcc\xb2\x3b\x71\xda\x16\xa0\x1f\x39\x94\x95\xa3\x2d\x07\x11\xb0\x6e\x78\x77\x02\x59\x53\xf8\xdd\xc6\xe9\xb0\xea\xb2\xdf\x9d\x95\xe4\x8b\x1f\x95\x7f\x52\xfc\x84\x2e\x13\xf4\x75\x46\x85\xf8\x0f\xd5\xaf\xd9\xee\x97\xaa\x4e\x43\xdd\xd5\x67\xd5\x53\x9f\xde\x02\x99\xff\x49\x81\x9c\xbd\xc6\xea\xd6\x6f\x03\xb7\x6d\xc9\x87\x6f\xf9\xfd\xd1\x93\xbf\x5e\x7c\xff\x46\xb0\x1f\xb6\x7e\x23\xe7\x9e\x12\x5a\x14\x98\xc7\x1e\xeb\xb1\xee\xab\x85\x15\x19\xab\xc4\xab\xfb\x8e\xfe\x85\x4a\x49\xb5\xdf\xbd\xba\x72\x17\x04\xf0\x70\x77\x73\x37\x05\x9d\xca\x35\x34\x94\xb6\xf1\xbd\xe9\x5f\x68\xad\xcf\x84\xd4\xf8\xf9\xf5\x5f\xff\xdb\x09\x83\xe6\x63\x3f\x0c\xea\xff\x9f\x7f\x07\x00\x00\xff\xff\x92\x07\xfb\x7c\x8f\x0e\x00\x00")
+var _templatesDashboardsHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x94\x52\x4d\x8f\xd3\x30\x10\xbd\xf7\x57\x0c\x56\x8f\x6d\x1c\xbe\x24\x54\x39\xe1\xc0\x2e\x47\x40\xa8\x20\x71\x9c\xc4\xd3\xc6\xc2\xb1\x23\x7b\x28\xa9\xaa\xfc\x77\xe4\x0d\xbb\x8d\x42\x5b\xb4\x72\xa4\xd8\x93\x37\xef\x3d\xbf\x8c\x7a\x71\xf7\xf9\xc3\xf6\xc7\x97\x7b\x68\xb8\xb5\xe5\x42\xa5\x17\x58\x74\xfb\x42\x90\x13\xe5\x02\x00\x40\x35\x84\x7a\xdc\xa6\xa5\x5a\x62\x84\xba\xc1\x10\x89\x0b\xf1\x6d\xfb\x71\xfd\xee\x2f\x32\x3d\x8a\x0d\x5b\x2a\xef\xfb\xee\x3b\x06\xb8\xc3\xd8\x54\x1e\x83\x56\x72\xac\x9f\x71\xd6\xb8\x9f\xd0\x04\xda\x15\xa2\x61\xee\xe2\x46\xca\x9d\x77\x1c\xb3\xbd\xf7\x7b\x4b\xd8\x99\x98\xd5\xbe\x95\x75\x8c\xef\x77\xd8\x1a\x7b\x2c\xbe\xfa\xca\xb3\xdf\xbc\xc9\xf3\xd5\xeb\x3c\x5f\xbd\xcd\x73\x01\x81\x6c\x21\x22\x1f\x2d\xc5\x86\x88\x05\xf0\xb1\xa3\x42\x30\xf5\x9c\x5a\xa7\xce\x1e\x14\xff\xc1\x8f\x16\x64\x64\x64\x53\xa7\x16\xb9\xb3\xd4\x57\xbe\xdf\x07\xa3\xb3\xd6\xb8\x2c\xd1\x3c\x9b\x76\x86\xbf\x20\xa3\x1f\xb3\xc9\xce\x84\x4a\x9e\xc3\x56\x95\xd7\xc7\x89\x8e\x36\x07\xa8\x2d\xc6\x58\x88\xda\x3b\x46\xe3\x28\x4c\x7c\xcc\x31\x0e\x0f\xa2\x54\xf8\x28\x4c\x7d\x67\x7d\x20\x91\xfe\x4c\xda\xc0\x01\x83\xc1\xca\x52\x54\x12\x4b\x25\xb5\x39\x5c\xe7\x0a\xfe\xf7\x4c\x69\x8e\xa8\xbd\x5d\xf7\x71\xfd\xf2\xd5\x05\xdc\x1c\x5b\xf9\x1e\x9e\x2e\x3f\x8d\xf2\x56\xd3\xc3\xf8\x88\xf2\x69\xa0\xe2\x05\xcb\xd3\xa5\x7e\xd9\xeb\x1f\xd3\x3a\x9d\x20\xa0\xdb\x13\x2c\x8d\xd3\xd4\xaf\x60\xa9\x61\x53\x40\x76\x56\x80\x61\xb8\xc9\xa0\xac\x99\x04\xac\xe5\xe9\x04\x4b\x9d\x7d\xc2\x96\x60\x18\x44\x39\x1e\xb7\xc9\x37\x0c\xc3\x98\xb2\x35\xff\x35\x45\x4e\xdf\x12\x56\xf2\xda\xc5\xae\x04\x72\xa1\x3c\x2b\x4d\x8e\x4a\x8e\x43\xa7\x64\xc3\xad\x2d\x17\x7f\x06\x00\x9a\x3f\xa7\x1a\x21\x04\x00\x00")
+
+func templatesDashboardsHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesDashboardsHtml,
+		"templates/dashboards.html",
+	)
+}
+
+func templatesDashboardsHtml() (*asset, error) {
+	bytes, err := templatesDashboardsHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/dashboards.html", size: 1057, mode: os.FileMode(420), modTime: time.Unix(1792219172, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesExploreHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xb4\x19\xfb\x6f\xdb\xb8\xf9\xf7\xfc\x15\x5f\x35\xef\x28\x21\x8e\xe4\xee\x36\x60\xb0\x2d\x17\x5b\xaf\x03\x36\x0c\x4b\xb1\x66\x03\x86\xa2\x07\xd0\xd2\x27\x8b\x89\x2c\xaa\x24\xad\xd8\x70\xfd\xbf\x0f\x9f\x1e\x7e\x52\x72\xd2\xbb\x33\x8d\x36\x16\xbf\xf7\x9b\xd4\xf4\xcd\x4f\xf7\xef\x1f\xfe\xf7\xf1\x03\xa4\x66\x99\xcd\x6e\xa6\xf4\x1f\x64\x3c\x5f\x84\x0e\xe6\xce\xec\x06\x00\x60\x9a\x22\x8f\xeb\x3f\x69\x4d\x97\x68\x38\x44\x29\x57\x1a\x4d\xe8\xfc\xe7\xe1\x6f\x77\x7f\x6e\x20\xe9\x3b\x35\xc2\x64\x38\xfb\xb0\x2e\x32\xa9\x10\xee\xe0\xc3\xba\xf8\x2f\x57\xf0\x13\xd7\xe9\x5c\x72\x15\x4f\x83\x1a\xe2\x80\x91\x89\xfc\x09\x52\x85\x49\xe8\xa4\xc6\x14\x7a\x1c\x04\x89\xcc\x8d\xf6\x17\x52\x2e\x32\xe4\x85\xd0\x7e\x24\x97\x41\xa4\xf5\xbb\x84\x2f\x45\xb6\x09\xff\x2d\xe7\xd2\xc8\xf1\x1f\x47\xa3\xe1\x8f\xa3\xd1\xf0\x4f\xa3\x91\x03\x0a\xb3\xd0\xd1\x66\x93\xa1\x4e\x11\x8d\x03\x66\x53\x60\xe8\x18\x5c\x1b\x42\x3d\x96\x51\x47\x4a\x14\x06\xb4\x8a\x42\x27\xd0\x86\x1b\x11\x05\x8f\x3a\x78\xfc\xba\x42\xb5\xb9\xfb\xd1\x7f\xeb\xbf\xf5\x97\x22\xf7\x1f\xb5\x33\x9b\x06\x35\xf4\xb9\xc0\x17\xec\x6a\x0d\x5a\x72\x91\xd6\x41\x92\xe1\x7a\x2e\xd7\x0b\x25\xe2\x8a\x1c\x49\xd1\x23\x95\x9d\xec\x19\xbc\x85\x4d\xdc\x9a\xd6\x3f\x10\x9c\x06\x07\xaf\x4d\xe7\x32\xde\x1c\xf1\x89\x45\x09\x51\xc6\xb5\x0e\x9d\x48\xe6\x86\x8b\x1c\xd5\x91\x1c\xe7\x30\x39\x2f\x9d\xd9\x94\xb7\x8c\x9d\xd9\xde\x95\x7a\x1a\xf0\xd9\x34\x88\x45\x69\xc1\x16\x71\xe8\x14\x22\xcf\x31\x76\x5a\x4a\xcd\x4f\x1b\xca\x76\x0b\x8a\xe7\x0b\x84\x81\xc8\x63\x5c\x0f\x61\x90\xf3\x25\xc2\x38\x04\xff\x13\xaa\x52\x44\xa8\x61\xb7\xeb\x94\x51\xc9\xe7\x33\x0d\xce\x21\x22\x99\xdd\xad\xf5\xdd\xdb\x3f\x58\xe0\xce\x61\xe7\x72\x0d\x58\x07\xb0\x03\x31\x37\xfc\x4e\xd7\x32\x84\xce\x76\xdb\x48\xb6\xdb\x75\x10\x3a\x27\x56\x45\xbb\x33\x3b\x42\xb4\xa8\xdf\x85\x9c\x22\xcf\x4c\x6a\xb5\xd8\xf1\x9a\xae\xb2\x3d\x3b\x85\x48\xf0\xab\xcc\x0e\xde\x41\xc9\xf2\xd8\xf2\x68\xbb\x05\xcc\xe3\x63\x47\x9c\x01\x35\x99\x75\x8a\x55\x72\x05\x22\x37\xa8\x4a\x9e\x41\x08\xdb\x2d\xf8\x7f\x6f\x7f\xee\x76\x93\x0b\x58\x5c\x17\x3c\x8f\x31\x26\x58\xcb\x76\x2c\xa3\xd5\x12\x73\xa3\x2d\xfb\x41\x00\x05\x37\xa9\x86\x67\x61\x52\xd0\x05\x8f\x50\x0f\xa1\xe0\x0a\x73\x93\xa2\x46\x0d\x52\xc1\xd7\x95\x34\xa8\xe1\x59\xae\xb2\x18\xe6\x08\x0a\x79\x0c\x5c\x9f\x13\xc2\x75\xa1\x50\x6b\x21\xf3\xd3\x2d\x52\x87\x22\x99\xcf\x33\x84\x10\x82\x9f\x3f\xff\xcc\xbe\xdc\x0e\x82\xc9\xcd\x05\x18\xe5\xee\x7d\x02\x21\x24\xab\x3c\x32\x42\xe6\x6e\xe9\xc1\xf6\x04\x8c\xbe\x22\x01\xb7\x84\x30\x0c\x21\x5f\x65\x99\x0d\x82\x96\x42\xb3\x52\x39\x30\x82\x61\xa7\x6a\xd3\xda\x59\xc9\x0e\x7c\xa1\xff\xa2\x14\xdf\xb8\xa5\xd7\x45\x98\x14\xd2\x46\x89\x7c\x41\x26\x2d\xfd\x0c\xf3\x85\x49\x61\x06\x23\xf8\xe1\x07\x28\x7d\x2c\x51\x6d\xdc\xbd\x0a\xc2\xe0\xb2\x8b\xd4\x91\x9c\xa4\xbb\x4c\x80\xa0\x21\x0c\x81\xd5\x1c\x2c\x82\xd3\x77\xe7\x4d\x6e\x7a\x68\xb5\xd2\xbd\x6b\xc9\x68\x06\x63\x60\x9c\x14\x7b\xb1\x29\x1a\x81\xc8\xd0\x64\xc4\xe5\x1c\x15\xeb\xd2\xa3\xe1\x5b\xc2\xef\xe1\x2d\xc1\x8f\xe0\x1d\x30\x8a\xe1\x05\xaa\x8a\x75\x92\x49\x6e\x5e\xc4\xfa\xd4\x1c\xe5\x29\x8a\x25\xbc\xf5\x6a\xb9\xe4\x6a\x73\x12\x34\xc3\x0a\xdd\x26\xac\x7e\x16\x26\x4a\xc1\xed\xda\x8f\xb8\x46\x60\x72\xfe\x88\x91\x61\xe3\x3e\x5d\xd9\x96\xc1\x2d\xdc\x57\x90\xfe\x13\x6e\xb4\x5b\x7a\x6d\x28\xdc\x02\xdb\x59\x94\xad\x89\xd7\x5e\xe8\xa7\xfd\x99\x68\xef\x23\xeb\x16\xd8\x97\x4e\x72\xad\x83\x7b\x09\x96\xfe\xa3\x14\xb9\xcb\x80\x59\x02\x27\xc6\x84\xaf\x32\xd3\x4b\xe0\x53\xc5\xc5\x2d\xbd\x6b\x2e\xb4\x78\xa8\x10\xf9\xb1\x77\x9a\xde\x30\x84\x25\x1a\x25\xa2\x6e\x5f\x0d\x7c\xfe\xc8\xd7\xee\xe5\x06\xad\x95\xca\xc6\xc0\x02\x5e\x08\x9a\x37\xb8\x89\x52\x36\xb4\x02\x2e\xd1\xa4\x32\x1e\x03\xfb\x78\xff\xe9\xa1\x03\x86\xda\x3a\xe6\xe6\x61\x53\x20\xa5\x49\x51\x64\x22\xe2\x94\xbc\xc1\xa3\x96\x79\x07\x12\x35\xba\x31\xfc\xe3\xd3\xfd\xbf\xfc\xda\x07\x22\xd9\xb8\xdb\x46\xbb\x31\x9c\xa9\x39\x3e\x51\x77\x5c\xfd\xbb\xf3\x2e\x08\xef\x3c\x3f\x96\x39\x1e\xaa\x87\x42\x6d\xb3\x0d\xad\x81\xcb\x7e\x47\x85\x15\x63\xe6\xf9\x3a\x95\xcf\xae\xe7\xe3\xb2\x30\x1b\xf7\x92\x6e\xfb\xf1\x79\x51\x60\x1e\xbb\x03\x97\x4d\x75\xc1\x73\x1a\xd7\xe8\x3f\xe6\xf9\x34\x32\xb9\xec\x63\x45\x10\x28\xfe\x6a\x81\xe1\x16\x18\xc8\xa4\x7a\xd2\xe8\x54\x3d\x32\x12\x98\xf7\x32\x46\x34\xf4\xf0\x19\xf3\x7c\x6e\x8c\x72\x19\x0d\x46\x6c\x08\x0a\xb5\xbf\x1f\xc5\x5a\xf6\x26\x45\x68\xfc\x09\xfb\x4d\xe6\xd9\xc2\xce\xf3\x13\x2e\xb2\x83\xa5\xd6\xa9\xea\xb2\x14\x55\x6c\x85\x54\xad\xd7\xa9\xf2\x15\xea\x42\xe6\x1a\xc9\x75\xf0\xed\x1b\x6c\x51\x29\xa9\xc6\xd5\x1e\x8d\x89\x2b\xfd\x80\x6b\xb3\x9b\x58\x49\xd9\x8c\x5e\x8b\xfe\xbe\xea\x8f\xb9\x34\x50\x88\xfc\xcc\x80\xe3\xea\x37\x69\x5c\xf1\xb2\xaa\x33\xb9\x96\x47\x0a\xf3\x18\x95\x35\x95\x32\xa1\xcd\x10\x4a\x9e\xad\x70\x08\x85\xc2\x44\xac\x6d\xb6\x20\x3b\x50\xa9\x82\xf0\xb4\x70\x11\x9e\xe7\x6b\xa9\x8c\x6b\x11\x8d\x88\xfb\x51\x2a\xb2\x58\x61\xee\xb2\x4c\x30\xcf\x47\x1e\xa5\x07\xd3\x77\xd9\x9d\x3a\x2a\x71\xf0\xab\x21\xf5\x3e\x71\x07\xae\x49\x85\xf6\x7c\xca\x1d\x97\x3d\xe1\x86\x79\x1e\x4c\x61\xd4\x45\x80\x56\x8b\xa3\x70\x29\x4b\xb4\x09\x68\x6f\x24\xb6\x1e\x59\x09\x93\x48\xf5\xe1\x44\xfc\x27\xdc\x0c\xa1\x12\xb1\x4b\x0e\x32\x1c\x0d\x4a\x10\x36\xd6\x85\x5b\x78\xc2\x8d\x5d\x14\x02\x2e\x69\x32\x20\xb3\x7e\x7e\xc2\xcd\x97\x6e\x38\x2a\x03\x10\x56\xd5\xe0\x3e\xa9\xca\x6b\x27\x68\x3d\x19\x58\xbd\x91\x88\xcc\xa0\xba\xee\x8f\xa3\x82\x6e\xf1\x04\x35\xee\x4e\xa5\x6c\xd6\x6c\x5d\x4c\x92\xb5\xbd\x8a\x9a\x7f\x1f\xfb\x46\x8b\x81\xeb\x4c\x33\x31\xab\x6a\x50\x33\x8a\x57\xe1\xd0\xd6\xa3\x93\x0d\xb2\x8e\x7d\xa7\x8a\x78\xfb\x56\x21\x72\x7d\xd8\x09\x32\x31\x73\x8e\xb5\x1d\x92\xff\xbc\x49\xaf\x9c\x47\x66\xa6\x0e\xdf\xd6\xc8\x7e\x44\xf2\xea\x1c\x13\x3a\xcc\xdb\x9d\x85\x5f\xdd\x3a\xd6\x7a\x98\x27\xe0\xd6\x34\x5a\xb3\xce\xfa\xad\xba\x97\x58\xe4\x1a\x95\xf9\x6b\x85\xdb\x90\xe8\xe1\xb3\x03\xcc\x34\x5e\xa1\x5b\xe9\xd0\x54\x73\xe2\xd1\x47\xcf\xba\xb3\xbb\xb9\xb1\x3c\x3d\x44\x4e\xed\x94\xca\xc9\x1e\xbc\x09\x3b\xe7\x81\xf6\x73\x8e\xd4\x4c\x10\x2f\xf7\x25\x81\xb7\xce\xbc\x82\xda\xcc\x2f\xd4\x3a\xce\xa9\xd0\x63\xb6\xef\xb8\x3d\xdc\x13\x70\xdb\xf3\x8f\x6f\x50\x1b\x97\x2a\x49\xe7\xe9\xe2\x7c\x04\xa7\xac\x3c\x8c\xd2\xdf\xbe\x35\x25\x23\x6c\x67\xea\x6b\x74\x68\x91\xa8\x87\x86\xec\x4c\xe7\x2b\x63\x64\x3e\xa3\x4b\x29\x33\x0d\x9a\x5f\x8e\xe7\x47\x99\x88\x9e\x5e\x56\x46\x8e\x3f\x85\x38\x6a\x45\xa4\xdc\x10\xd8\x3f\x45\x8e\xef\x89\xbe\x6d\xe2\x3c\xff\xec\x6c\x0d\xfe\x7a\x60\x5d\x98\xea\x4d\xb8\x9f\xdd\xe9\x28\xb6\x7f\x56\x8f\xdc\x27\x8f\xaa\x53\xe1\x2f\xb0\x1d\xf5\xfc\xdf\xc8\x74\x34\x7d\xfc\xc6\x56\xdb\xbd\x34\x57\x56\x19\xbb\xd6\x79\x6d\x19\x56\x57\x4b\x99\x24\x2e\xab\x0c\xd3\xa7\x4e\x85\x6b\xe4\x62\x91\xe1\x7b\x2a\xdd\x2e\x9b\x2b\x9e\xd3\x3c\x7f\x08\xf5\xc6\xa9\x7d\x54\x8e\xd3\xa5\x05\xbf\xe2\x84\x8a\x73\xe3\x5a\x67\xba\xca\xea\x6b\x20\xc7\x9b\x5c\xc7\xba\xd0\xf5\x3b\xfc\xdf\xde\xdb\x7c\x6e\x02\x80\xc6\xea\x31\xcd\x89\x14\x07\x5f\x20\x84\x37\xfd\x10\xfd\x62\xd2\xaa\x0e\x05\x0d\xee\x15\xad\xba\xfa\xfb\xab\xcb\x3a\x55\x4c\x4a\x0e\x08\xdb\xa3\xf9\xfe\x3c\x6e\xe7\x40\x08\x11\x66\x99\xa5\xc4\xd6\xed\xbd\x43\x32\xca\x7a\xc2\xab\xeb\x78\xdd\x39\x70\x6d\xfa\x2c\x5f\x83\x1f\x87\x5a\x94\xd2\x2d\x6a\xcc\x86\x70\x46\x8a\xed\x1b\x04\xd1\xb4\x4b\x40\x4b\xa3\x79\x10\x4b\x94\x2b\xf3\x72\xe7\x57\xac\xea\xa4\x3a\x13\xa3\x87\xd1\x6e\x08\x6f\x47\xa3\x91\x37\x79\x8d\x33\x5e\x9b\x15\xe4\x0b\x59\x20\x9d\xd7\xdf\x7c\x77\xf4\x5d\x26\x74\x4b\x89\x0d\x2b\xea\x3d\x5a\x9e\x45\x40\x55\x7e\xea\xda\xe0\x5e\xc3\x4c\xa0\x06\xb9\x62\xfc\xfa\x14\x75\x28\xba\x16\x8e\x43\x28\xeb\x62\x4c\xf9\xe6\xb3\xef\xc8\x8c\xf6\xaf\xae\xec\xb2\x9c\xee\x28\x57\x2d\x67\x3b\x9b\x3a\xe4\x25\xba\x73\x0f\x61\xe0\x32\x7f\x2e\xd7\x7e\x73\xfb\xfe\x8a\xa3\xc0\xd9\x31\xa0\x3e\x99\x1f\xdf\xdd\x33\x8f\x82\xa6\xf9\x31\xb9\xaa\x91\xcd\xb4\x24\xd9\xc1\xb2\xbe\x51\x88\xcc\x1b\x1e\x2e\xa4\xdb\xc0\xfa\x52\x9d\xc2\x77\x43\x60\xec\xba\x9d\x22\xc5\x9f\xb3\x63\x43\xd9\x34\xbc\xb4\xcb\xcb\x0e\xac\xc7\x96\xad\xce\x47\x93\x1b\x0b\x54\x25\x47\x23\x3b\x84\x40\x8c\x6c\xf6\xb3\xe3\x0e\xfc\x05\x1a\xba\x7a\x70\xeb\xdb\xab\x92\x2b\xcd\x86\x70\x71\x71\x74\x71\x13\x14\xcb\xa8\x4b\x6c\x5a\x24\xc5\x49\x4d\xd1\x86\x67\xa4\xf9\x91\x07\xea\xd7\x23\x6d\x65\xbb\xb0\xf6\xf1\xb2\x38\x29\x24\xcf\x4d\x6e\x7e\x41\xb3\x79\xcd\x9d\xcd\xaf\x7c\x6f\xd3\x9a\x88\xc7\xf1\x4b\xed\xd3\x77\x5d\xd3\x95\x02\xbb\xfe\x00\xae\x82\xf7\x7c\x90\xd2\x68\xda\xf7\x3b\x6e\x05\x30\xdc\xbf\xfe\x39\x82\x3c\x7d\xaf\x3a\x0d\xea\x97\x94\xd3\x20\x35\xcb\x6c\x76\xf3\xff\x01\x00\x04\xaf\x32\xfa\x9a\x1e\x00\x00")
+
+func templatesExploreHtmlBytes() ([]byte, error) {
+	return bindataRead(
+		_templatesExploreHtml,
+		"templates/explore.html",
+	)
+}
+
+func templatesExploreHtml() (*asset, error) {
+	bytes, err := templatesExploreHtmlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "templates/explore.html", size: 7834, mode: os.FileMode(420), modTime: time.Unix(1792222708, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _templatesIndexHtml = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xcc\x3c\xfd\x93\xdb\xb6\x72\xbf\xeb\xaf\x58\xeb\xb9\xa1\xf8\x2c\x51\xba\xe4\xb5\xd3\xd1\xd7\xcd\x9b\xd8\x99\xb6\x93\x26\x99\xd8\x4d\xa7\xa3\xde\x64\x20\x12\x27\xc2\x47\x11\x2c\x00\x7d\xe5\xac\xff\xbd\xb3\x20\x48\x51\x14\x40\x4a\x67\x27\x2f\x96\xc6\x27\x91\x8b\xc5\x62\xbf\xb1\x58\x6a\xfa\xea\xed\x8f\xdf\x7e\xf8\x9f\x9f\xde\x41\xac\xd6\xc9\xbc\x33\xc5\x3f\x90\x90\x74\x35\xeb\xd2\xb4\x3b\xef\x00\x00\x4c\x63\x4a\xa2\xfc\x23\xbe\xa6\x6b\xaa\x08\x84\x31\x11\x92\xaa\x59\xf7\xbf\x3e\x7c\x37\xf8\x57\x03\x89\xef\xa9\x62\x2a\xa1\xf3\xe7\x67\x08\xde\x12\x19\x2f\x39\x11\x51\xf0\x01\xaf\xc1\xf1\x08\x03\x78\xb7\xcf\x7e\x21\x02\xca\x7b\xd3\x61\x3e\xe0\x84\x20\x61\xe9\x13\xc4\x82\x3e\xce\xba\xb1\x52\x99\x1c\x0f\x87\x8f\x3c\x55\x32\x58\x71\xbe\x4a\x28\xc9\x98\x0c\x42\xbe\x1e\x86\x52\xde\x3f\x92\x35\x4b\x0e\xb3\x9f\xf9\x92\x2b\x3e\xfe\xdb\x68\xd4\xff\x66\x34\xea\xff\xf3\x68\xd4\x05\x41\x93\x59\x57\xaa\x43\x42\x65\x4c\xa9\xea\x82\x3a\x64\x74\xd6\x55\x74\xaf\x70\x68\x95\x64\x19\x0a\x96\x29\x90\x22\x9c\x75\x87\x52\x11\xc5\xc2\xe1\x47\x39\xfc\xf8\x7f\x1b\x2a\x0e\x83\x6f\x82\xbb\xe0\x2e\x58\xb3\x34\xf8\x28\xbb\xf3\xe9\x30\x87\x6e\x1f\x1e\x7d\xf3\x82\x41\x34\xe3\x61\xdc\x34\x4e\x73\xe7\x62\x6d\x39\xbb\x0a\x3c\xa1\x94\xc3\xc7\x84\xee\x97\x7c\xbf\x12\x2c\xd2\xe8\x70\xc9\x0d\x2c\xb0\xa3\xad\xc1\x5b\xa6\x39\xd1\xfb\x85\x10\x46\xa5\xd2\x9c\x10\x4e\x87\x27\x15\x9c\x2e\x79\x74\xa8\xcc\x13\xb1\x2d\x84\x09\x91\x72\xd6\x0d\x79\xaa\x08\x4b\xa9\xa8\xd0\x51\x87\x49\xc9\xb6\x3b\x9f\x92\x62\x62\xba\xcf\x12\x2e\x68\x77\xfe\x2e\xff\x00\x5b\x22\x18\x59\x26\x54\x4e\x87\x64\x3e\x1d\x46\x6c\x6b\xc1\xc5\xa2\x59\x97\x24\x54\x28\xd9\x2d\xf0\x9a\xaf\xb6\x21\xcf\xcf\x20\x48\xba\xa2\xf0\x9a\xa5\x11\xdd\xf7\xe1\xb5\xe0\x3b\x18\xcf\xaa\x06\xf2\x3d\x39\xf0\x8d\x0a\x7e\xe6\x3b\x09\xc7\xa3\x93\x7a\xc1\x77\xb5\xb5\xd9\x27\x08\x79\x82\x13\xe0\x44\xc1\xb7\x3c\xb9\xc0\x59\xc7\x1b\xf2\x64\xb0\x97\x83\xbb\xaf\x01\x3f\xc9\xf5\xe0\x5f\xf4\x87\x75\x34\xf8\x9b\xfe\x90\xac\x06\xcf\xcf\xaf\x43\x9e\x04\xef\xd9\x6f\xf4\x78\xb4\x10\x51\x47\xb9\xe4\x7b\x07\x54\x1d\x92\xee\x33\x2e\x54\x03\x30\xbe\x4f\x22\x23\x19\x1b\xe6\x43\x86\x86\xa6\x7f\x7f\x7b\x3c\xde\x3f\x72\xb1\x26\x6a\x16\xca\x6d\x17\x22\xbe\x4b\x13\x4e\xa2\x79\x28\xb7\x28\xc7\x2f\x82\xf9\xa3\xe4\x69\x05\x35\x7e\x6d\xc4\x6d\xd1\x04\x17\x07\xb4\x07\xec\xce\xcd\xa4\xda\x59\x1e\x8f\xd7\x8c\x47\x45\xac\x90\x5a\x6a\xe3\x8e\x45\x2b\xaa\xac\xda\xe8\xa2\x21\xa1\x2b\x9a\x46\x5d\x88\x88\x22\x83\xfc\x4b\x89\xfb\x7b\xfd\xd5\x29\x76\xb7\x1e\xa6\x64\x4d\xb5\x22\x6a\xd5\xa1\x82\x51\xab\x2a\x36\xd3\x34\x60\x8a\xae\x5b\xa6\x76\x8c\x5c\xf2\x3d\x84\x44\xd1\x15\x17\x87\xc1\xf3\xb3\x21\x0c\x8e\xc7\x56\xd6\x34\x20\xc5\x45\xa1\xb0\xcc\xf2\x5a\x25\x75\x85\x32\x18\xee\xd1\x34\x6a\x62\x4f\x0b\x92\x2a\xa9\x31\x25\x89\x8a\x1b\x57\xe9\xb8\xe5\xb8\x6c\xa7\xce\x02\x7c\x09\x58\x03\x32\x91\xef\x7c\xd4\x70\x08\xef\x30\x92\x40\x24\xc8\x4e\xc2\x9a\x49\xc9\xd2\x15\x6c\x49\xb2\xa1\x12\x88\x84\xdf\xa8\xe0\xb2\x0f\x4b\x41\xc9\x13\xa8\x98\x42\xc2\x52\x0a\x2c\x95\x8a\x92\xe8\x0c\x95\xc6\x13\x7c\x60\x6b\x1a\x7c\xcf\x52\x1a\x64\x82\x2b\x8e\x81\x2c\x40\xdc\x30\x83\xc7\x4d\x1a\x2a\xc6\xd3\x5e\x44\x13\x45\x7c\x78\x3e\x1b\x8e\x6f\x7d\x03\x66\xe6\xef\xa7\x4f\x30\x9a\x5c\xc0\xa8\x18\xd3\x90\x84\x12\xd1\xf3\x2f\xef\x6e\x89\x80\x1d\xcc\x72\xa8\x9d\x0b\x22\x21\x07\x2a\x64\x01\xb6\xa2\xea\x17\x26\xd9\x32\xa1\xdf\xeb\xeb\xb6\x51\x8f\x5c\x40\x4f\x0f\x85\x19\x8c\x26\x90\xc0\xd4\x60\x09\x12\x9a\xae\x54\x3c\x81\xe4\xcd\x1b\xdb\x9a\xce\xe6\x84\x99\x19\xb5\x48\x1e\x2e\x27\xc1\x17\x7b\x84\xde\xab\x9c\x95\x4c\xfe\xc0\xd3\x77\xeb\x4c\x1d\xfe\x2e\x04\x39\xf4\xf4\xc8\x20\x17\x8d\xef\x9a\x0a\x5f\x18\x8f\x59\xba\xa1\xf6\x19\x8e\x1d\xcb\xc5\x9c\x13\x92\xaa\xf7\x3a\x69\x30\x73\x69\xaf\xf6\x03\x59\x53\x7f\xe2\x1e\x14\xaa\x7d\xb0\xa4\x2b\x96\xfe\x44\x54\x6c\xe3\x5d\xc1\x81\x43\xc1\xf0\x62\x29\x3a\x3a\x37\x0c\x60\xc5\x00\x9e\xa1\xde\xc8\x60\xc7\xd2\x88\xef\x30\x14\xba\x07\x7d\x2c\x78\x6c\x38\x55\xc8\xc7\x39\x60\x45\x32\x9c\x47\xb8\xf8\xb5\x8b\x59\x42\xa1\x37\x18\x30\x98\xcf\x60\xf0\x35\x7c\xf5\x15\x0c\x06\x1f\xf1\xcb\xa8\x49\x0a\x48\x4c\x54\x23\x66\xf1\xd1\x21\xf6\x42\xf4\x51\x70\x80\xd9\x0c\xd2\x4d\x92\x34\xe1\xc6\x57\x1b\xdd\xd7\x69\x83\x5b\x23\x8a\x35\xe0\x2c\x8b\x1e\x83\x37\x70\xe7\xc3\x5f\x61\x07\x6f\x72\xfb\xec\xc3\xa1\x17\x05\x07\xbf\x65\x45\x5a\xb1\x58\xfa\x41\x90\x54\x32\x14\x63\xaf\x51\x77\xf1\x9d\x2d\x46\x0f\xf0\x66\x06\xbb\x97\x90\x8c\x5c\x5c\x91\xac\x6d\x8e\x52\x75\xd7\x7c\x4b\x3f\xf0\x80\x64\x59\x72\xe8\x15\x57\xfb\x90\x39\xf4\x12\xdf\x47\xa0\x89\xa4\xd7\x4e\x80\x0e\xf3\xd6\x09\x9c\x77\x72\xa1\x3f\x92\x44\x3a\xe4\x79\xec\x34\x12\x23\x95\xe0\x4f\xd4\x66\xa5\x97\x03\x05\x55\x1b\x91\x5e\xf8\xf5\x5f\x7f\x95\x9b\x8c\x8a\x5f\x7f\xd5\x7e\x3d\x08\x49\x92\xe8\x65\xd5\x70\x1e\xcf\xbf\xa2\x26\xe5\xd9\x11\xfa\xdd\x67\xcb\x5d\xc9\x37\x22\xa4\xfa\xee\xd9\x1e\xf6\xbd\xb9\x7e\xb4\x8c\x49\x88\x54\x18\x71\x1c\x28\xf3\x0c\xf2\x17\x34\xbd\x6a\xfc\xd9\xda\x94\xa3\x8c\x84\x82\x6f\xd2\x48\x02\xdd\x52\x71\x50\x31\x46\xc3\x25\x4d\xf8\x0e\xee\x46\xa3\x11\x28\x0e\x3c\xa5\x10\xd1\x90\xad\x49\x02\x59\x42\x42\xda\xb7\xe1\xda\xc5\x2c\x8c\x21\x66\x11\x95\x20\x88\x62\x5c\x02\x49\x23\x78\x14\x44\xd3\x20\x2f\xc6\xa0\xde\x6e\xe1\xd5\x0c\x46\xe8\x60\xfe\x93\xa8\x38\x20\x4b\xd9\xdb\xfa\x30\x85\x3b\x1b\xc1\x15\x11\xbd\xd9\x06\x8a\xff\x24\x68\xc8\x24\xae\xef\xeb\xdb\xc5\xfb\x9d\xe6\x94\x0c\x24\xeb\x6d\xdb\x05\x89\x2a\xfd\x6d\x4c\x84\xaa\x72\x95\x45\x7d\x93\x37\xd8\xa8\xc5\x61\x32\x4f\x44\x67\xb0\xb0\xf8\x0c\x1d\x61\xd1\xe3\x8f\x26\xc0\x60\x0a\xe7\xde\x1b\x98\x3b\xba\xe6\x68\x83\x6c\x23\xe3\x9e\x1d\x02\x5f\x09\x59\xd2\x64\x0c\xde\x7b\x0d\x0d\x1e\xbc\x01\xd6\xef\x38\x80\xcd\xec\x63\xf3\x77\xc1\x1e\xac\x90\xc7\x5b\x18\xfd\xba\xe7\xfd\xc5\x7b\xc3\x22\x3f\x20\x51\xf4\x2d\x46\xd6\x9e\xa7\x77\xf1\x3a\xa5\x1a\x60\x61\x47\x79\x7e\xa0\x2f\x39\xd6\x81\xd9\xd4\x18\x3c\x85\xb6\x88\x83\x3c\xfb\x02\xc8\x9e\xca\x31\x2c\xbc\x84\x3e\x2a\xaf\x0f\xde\x92\x2b\xc5\xd7\xde\x83\x1d\x5a\xb1\xf0\xc9\x88\x7f\x0c\xcf\x80\x83\xc6\x67\x96\x73\xb4\x8f\xc3\x0d\xcb\xd8\x30\xbf\xdf\x69\x63\x4d\x4d\x8b\x86\x43\x90\x31\xdf\x7d\xc0\x2d\x3f\x08\x9a\x46\x98\x8f\xe9\xf4\x92\x48\x05\x9b\x2c\x22\x8a\x02\x7f\x04\x02\x0a\x41\xfa\x20\xb9\x50\x34\x82\xe5\x01\x93\xd0\x3a\xaa\x30\xe6\x92\xa6\xb8\x63\xde\xac\xd3\x31\x02\xa5\x9b\xf5\x92\x0a\xd8\xc5\x34\xc5\x01\x82\x02\x93\x68\xb9\xfd\x7a\x8a\x8b\x1e\xe4\x0c\x9d\x56\xd4\x92\xb4\x8a\x7e\x87\x2e\xb5\x36\xd4\xce\x20\x0c\x90\x27\x3d\x2f\xbf\xe0\x59\x94\x03\xc1\x71\x25\x15\x60\xfc\xea\x02\x15\x58\x96\x98\x99\x09\x02\x11\xc8\x84\x85\x56\xe7\x8d\xce\x03\x11\x05\x39\x0b\x1a\x73\x13\x44\x1a\x20\x70\xaf\x5c\x1a\xe9\xc3\xd2\x05\x5e\xd0\xb2\x87\x19\x90\x20\x5c\x54\xa6\x79\xe8\xeb\x9c\x6e\x59\xbb\x3a\xe9\x38\xd0\x68\x9f\xfc\x84\x88\xf6\x81\x76\x75\xe9\x26\x49\xe0\x5e\x7f\x1b\xc3\x3e\x50\x7d\x78\x42\x84\x87\xb3\xbb\x07\x7d\xf7\x10\x28\x37\x5e\x5c\x3e\xe2\x35\x63\x3e\x7d\x82\xa7\xab\xd3\x28\x63\x9d\x95\xf1\x3e\x0c\xa0\x57\x41\xe0\x9e\xf7\xd8\x71\xdc\xd0\x2b\xe5\x22\xa2\x02\x66\xf0\xb4\x87\x29\x12\x74\x0f\x83\x3b\x18\x6b\x4a\xe7\xf9\x77\xfc\x3a\xf2\x27\x4e\x2c\x86\x36\xcd\xf2\x88\xca\x10\xee\x61\x90\x63\x1d\x83\xfe\x3b\xb9\xc1\x2d\x5d\x5c\x0a\x03\x8a\x9b\x0b\x9b\x3e\x21\xf9\x58\xfa\xd3\xf4\xbf\xee\x79\x53\x25\xe6\xd3\xa1\x12\x73\xcf\xc7\x44\x86\xa6\x51\xaf\x3b\x55\xb1\xd9\xf5\x7a\xb8\x1f\xf7\x10\x20\x9e\x77\x2d\xd8\x8c\xfe\xc6\xc1\x23\x17\xef\x48\x18\x9f\x14\x4f\x3b\xe5\x3e\x30\x97\x90\x90\x0e\x15\x17\x34\xc4\xf9\x14\x9e\x1f\x60\x65\x34\x1f\xec\x07\x61\xc2\xc2\xa7\x13\xca\x26\x79\x9f\xd9\x5c\x1f\x9e\x0b\x87\xc1\xfa\x80\xec\x1d\x43\x45\x8d\x51\xfc\x0c\x43\xf1\xab\x92\xfd\x36\xbe\x16\xff\x4a\x6f\xd6\x0b\xfd\xeb\xa5\x62\xb3\xdd\xd9\xcc\xcd\x0f\x7c\xa9\xf8\x14\x40\xaa\x9a\xe1\xe1\x12\x3c\x18\x83\x47\x64\x68\xf3\x28\x6e\x8d\xcd\x45\x5d\x48\x56\xc5\x96\xc1\x36\xea\xc3\x62\x44\x3e\xde\x02\xa1\x7d\xcd\x85\xd4\x85\x6b\x79\xc6\xe7\x39\x75\xee\x35\x6a\x5d\x74\xa1\x75\xd1\xbc\x6b\x54\x42\x04\xd2\xb7\x90\x81\x6f\x11\x84\x97\xfa\x17\xd2\x66\x07\x81\x75\xdc\x72\x6e\x6f\xaa\x22\xd4\xc0\x68\xee\x55\x62\x38\xa2\x08\x12\xf8\xf4\x09\x3c\x54\x4c\xbe\x5a\x25\xd4\x44\xf7\x94\x0f\x50\xdf\xbc\x3e\x68\x20\x55\x7a\x94\x9c\xd8\xf3\x8b\x70\x0f\x5e\xca\x75\x19\x10\x85\x98\xdf\xf4\x6f\xd4\xa5\x52\x22\x82\xef\xae\x10\xa2\x2d\xb5\xd3\x25\x47\x67\x8e\x1e\xf3\x5d\x5e\x94\xac\x67\x7e\xda\x18\x65\x1f\x32\xce\x52\x25\x6d\x3c\x3d\x61\x87\x59\x25\x19\x0a\x13\x2e\xa9\x54\x3d\x2f\x58\xf2\xbd\xe7\x07\x8f\x2c\x8d\x7a\x5e\x90\x43\xda\xd4\x18\xed\x25\xbf\x1b\x10\xa5\x44\xcf\xab\x54\x4e\x3d\x1f\x03\x87\x87\x9b\x62\xcf\x25\xd7\xdc\xa9\x4e\x3a\xed\xa6\x71\x9a\x49\x2e\x58\xf4\x80\xa8\xf3\x65\x06\x1f\x39\x4b\x7b\xde\xff\xa6\x9e\x73\x2f\x5b\x1d\x67\x19\x36\x69\x18\xe4\x76\xcb\x65\x1e\x2b\x6f\x77\xa5\x85\x08\xb0\xa8\xab\x05\xd0\xad\xd4\x2d\x3d\x53\x62\xc5\x9b\xde\xdc\x72\x03\x65\x63\x8a\x9a\x96\xbb\x85\x25\x62\xbd\x31\xff\xdf\x16\x06\x8a\x7f\x38\x49\x10\xc6\x2c\x89\x04\x4d\x4b\x51\xeb\x29\xaa\xb9\x71\x59\x3f\xc6\x54\xbd\xc7\xe0\x9f\xe0\x6e\xe4\xdf\x8e\x56\xd3\x76\x16\x31\xdc\x28\x0a\xb5\xca\x2d\x1e\x11\xde\x60\x7f\x97\xfa\x33\x1c\x62\xee\x69\x52\x64\xcc\x66\x9f\xe8\x01\xb3\x5c\xa2\x20\x62\x12\x2d\x95\x08\x1a\x81\x54\xe4\x00\x5c\xe7\xa9\xfa\x78\x57\x5d\x66\xd3\xc3\x21\x2c\x37\x0a\x52\xae\x80\xe5\x80\x39\xa9\x1d\xc7\x0a\x4a\x36\xf8\x01\x3d\xd3\x12\xa7\x7a\xbc\xce\xf7\xf0\xc6\x81\xf5\x70\x07\x96\x9b\xb2\xd9\x81\x61\x24\xcc\x2f\x2c\xd8\x43\x70\x28\xf2\x33\x1b\x1f\xda\x9d\x4c\xbe\xbb\x30\x1b\xf7\x92\x36\x65\xa3\xcd\xa4\x40\x0a\xee\x21\xa5\x3b\x78\x4b\x14\xed\x29\xf8\xab\xde\x8d\xa3\xbb\xfd\x9e\x87\x24\xa1\x88\xea\xbd\x12\x2c\x5d\xf5\x7c\x8c\x80\x29\xee\xde\xbd\x56\x3a\x30\x68\xff\x9b\xae\xd8\x57\xe9\xc8\x6b\xf8\x0d\x9b\xd8\x2d\x33\x75\x8a\x1a\x4a\x13\x4a\x13\x65\xc9\x74\xe2\x86\xdd\xab\xc6\xb7\x88\x03\x89\xae\x22\x6e\xe7\x28\xbe\x5f\xe7\x82\x35\x45\x93\xfe\xb9\x33\x46\x9d\xb7\xfa\xe0\x62\x11\x79\x25\xa6\xea\x87\x2f\x67\x28\x3c\x60\x0e\x1b\xc4\x44\x1a\xc3\xc4\x43\xe3\x8d\x1c\xe4\xd7\xdd\x1e\xb0\xc9\xd7\xda\xed\xa5\xa0\x0e\xcf\xfa\x60\x06\x3d\xbd\x0c\x8c\xac\x8b\x07\x8c\x0b\x89\xa2\xe2\xc4\x50\xbc\xd9\x3e\xf5\x89\xbd\x08\xff\x80\x5a\xfc\xea\xfc\x52\xb0\x71\x90\xe7\x60\x09\x12\x88\xc7\x5b\x33\xc3\xc4\x7a\x00\xb3\x8f\x5a\xf2\xbd\x31\xad\x13\x13\x13\xea\xf5\xf5\xb9\x66\x61\x62\xf3\xbc\xf6\x53\xbd\x84\x19\x03\x72\xc1\x7c\x77\xd0\x84\xd8\x4d\xdc\xcc\xf5\xaf\x48\x91\x35\xaa\x35\xc9\xae\x67\x1b\x2e\x0f\x13\xee\x73\x26\x4d\xda\xd8\x8c\x68\xe1\x0d\x78\x63\x5d\x58\x89\x03\xfd\x05\x7a\xb8\xbb\x06\x49\x69\xaa\x2f\x9f\xcc\xbe\x17\x07\xca\x47\x10\xbf\x66\xa4\xc5\xeb\xe8\x9b\x60\x39\x01\xcf\xf7\xdb\x4d\xc2\x61\xdc\x7f\xd7\xdd\x01\x55\xe3\xce\xfb\x05\x6c\x1c\xc0\x95\x2f\x49\x9a\x16\x7b\x9e\xbf\xe4\xa0\x58\x92\xc9\xe3\xb1\x11\xa0\x41\x51\x48\x68\x6e\xdd\xc1\xbd\x2e\xf3\x19\x41\xb1\xc2\x6c\xa4\xae\x87\xb2\x74\x65\x53\x13\x83\xf6\xc2\x73\xe8\xeb\x2e\x91\x21\xd1\x92\xa5\x21\x85\xd9\xc9\x3f\xea\x11\x41\xb3\x97\xbc\x9c\x1f\x5f\xf9\xfa\x8b\x00\x58\xcb\x11\x34\xda\x22\xcc\x17\x19\xb7\x15\x4d\xb9\x9c\x20\x45\x21\x43\x0f\xa5\x9f\x5f\x90\x78\xc1\x1f\x57\xf4\x41\x17\x99\x0c\xcd\x5b\xad\x14\x66\x45\x08\xa2\x3f\x59\xe7\x70\xa5\x02\x39\xa2\xdd\xa5\x07\x66\x91\x8b\x89\x46\x60\x8e\x84\xf4\x94\x91\x34\x49\xcf\xe5\x32\xae\x50\x54\x5d\xe3\x41\xe1\xfd\x37\x5d\xbe\xe7\xe1\x13\x55\xbd\xee\x0e\xbb\xaf\x12\x8c\x6d\x31\x97\x6a\x8c\x5d\x5d\x3f\x61\xdd\xe8\x78\x1c\xe6\xbb\x69\x79\x1f\xcd\xce\x7b\xbd\xf0\x9c\x0e\x4f\xd8\x6b\x13\xee\x64\xc0\xd3\x35\x95\x92\xac\xce\xa2\x2d\x6d\x2e\x67\x21\x4d\xff\xf1\xfe\xc7\x1f\x82\x0c\xfb\xcd\x7a\x54\xef\x9c\xfd\x89\x35\x47\x36\x23\x02\x41\xb1\x43\xc3\xc5\x65\x5c\x0d\xce\x6c\xc0\x5c\x2a\x78\x7d\x7a\x7e\x32\xf0\x92\x02\x62\xa2\x45\x33\x9d\xd2\x1d\x8c\xcb\x84\xa0\x0a\x7c\x0d\x31\x25\x7c\x5c\x06\xac\xba\x02\xc6\x4c\x2a\x2e\x0e\xae\xb9\x4f\xb1\x56\x2e\x0c\x68\xc0\x1e\x10\x5b\xf1\xad\xcc\xc5\x66\x2d\x47\x8f\xb7\x87\x5d\xcb\xc4\xb3\x53\x99\xbf\x20\x3d\x60\xfd\x13\x31\x0e\xf9\xa1\x47\x32\xb5\xd5\xd9\x09\x78\x31\x72\xc4\x90\xe2\x04\xe7\x7c\x66\x53\x76\x3f\x3b\x03\x80\x01\xdc\x3d\x04\x58\xfb\xb6\x63\x42\xfe\x15\x48\x1a\x37\xf5\xa7\x4d\xac\x6d\x5d\x49\x65\x89\xe7\xa1\xd3\x99\x51\xd5\x62\xa1\x5c\x9c\x91\x6c\xa7\x16\x5f\x47\xdf\xbf\x56\x46\x36\xd7\x52\x68\x5c\x62\x29\x6c\xe4\xf7\x5c\x04\x9f\x8c\x21\x90\x57\x72\xca\x80\xb3\xbe\x99\x36\x90\xe5\xa7\xec\xea\x55\xd4\xe6\xce\x7e\x3f\x7d\x46\x2d\x0c\xcb\x1c\x4d\x2e\xcc\x8c\xcc\xa1\x85\x48\x54\x08\x5f\x7d\x65\x2a\x84\x05\x5d\x53\xa8\x51\xda\x44\xe4\x70\xa8\x23\xf0\x69\xb7\x47\x60\xc7\x92\x28\x24\x22\x82\x35\x55\x82\x85\x7d\xdc\xea\x09\x85\xfb\xb7\x35\xec\x98\x8a\x9b\x70\xd5\x5b\x71\xd2\x48\xf7\xe8\x9c\x76\x89\x40\x56\x84\xa5\x4e\x14\x67\x76\x68\x96\x75\xa6\xce\xba\x07\xe3\x4a\x95\xae\xf6\x30\xd8\x39\xe8\x52\xd2\xe2\x1f\x92\x83\xb6\x2b\x4b\xeb\x2e\x98\x8c\x59\xef\xbd\xb9\x86\x07\xff\x63\xeb\xf1\x60\xad\x1b\xe3\x1c\xc5\x4d\x72\x3a\x1d\xef\xe5\x47\x86\x9a\xaa\x73\x4b\x6f\xed\x1e\xa8\xb0\xe6\x19\xc7\x8f\x21\xd3\x9e\xa9\x0f\x87\xb1\x3e\x43\xa8\x85\xf9\xab\x2d\xdf\xad\xd1\xa7\x24\xc5\xac\x95\x15\xb9\xe5\x5b\x7d\xe8\xa4\xcf\x0d\x4f\xa7\x88\x4d\x25\x24\x7c\x85\x67\xfe\xbd\x40\x59\x9e\xe0\xba\x07\x5e\x58\x14\xaa\xd7\xa4\x73\xfd\x42\xd0\xd6\x5e\x59\x0f\xd3\xac\xaa\xdb\xa4\x0c\xf5\x33\xe3\x9a\x16\x34\x9e\x1a\xdb\x54\xc1\x9a\x39\x34\xaf\xe6\x1f\xcf\xcb\xda\xa2\x61\xde\xec\x4b\xab\x43\x16\xa3\x3c\xa0\xc2\x74\x76\x0a\xc4\xe6\x26\x7b\x68\xe3\x5c\x93\x57\x6e\x66\xd8\xe5\x5c\xa7\x53\xce\x92\xa8\x5b\xf8\x10\xe6\xf2\x2b\x50\xf8\x93\x9b\x02\xe8\xea\xd6\xf8\xf9\x82\xe8\xd2\xac\xf1\x61\x59\x8a\x29\x50\x55\xf7\x1d\xda\xb4\x61\x45\x36\x2b\x3a\x90\x6b\x92\x24\x2d\x6d\x02\xb6\x76\x01\x3d\xda\x7b\x91\xeb\xfe\x6c\x2d\xad\xaf\xcc\x71\x48\x62\x00\xb6\xe5\x31\xc9\xa4\xd3\xa2\xc0\xe5\x31\x71\x23\x6f\x83\x1c\xba\x1c\xe4\x5f\x4b\x3b\xc6\x33\xa7\xd2\xa8\x3f\x89\xd2\x9c\x6d\xd1\xb5\xc7\x39\x6d\xd1\x6f\x96\xa9\x13\xde\xad\x9c\x58\x58\x2f\x6b\x81\x45\xe5\xc0\x79\x06\xda\xb9\x06\xf9\xe7\xe9\x47\x98\x17\x26\xea\xd0\xb5\xf3\xb5\xe2\xb6\x3f\xb9\x4e\xec\x06\x9f\x0c\xe4\xd6\xb9\xc1\xfb\x83\xe5\x6f\x29\xe4\xda\x04\x84\xe5\xda\x52\x40\x6d\x1a\x51\x08\xf0\x73\xb4\xca\x51\x3e\x92\x19\x11\x4f\x18\x26\x4f\x98\xcc\x01\x1e\x8b\x4e\xd2\xc5\x3e\x53\x6f\x70\x82\x6d\x4a\x94\xcc\xf9\xca\x92\x84\x4f\x8f\x2c\x49\x20\x24\x42\xe7\xde\x98\x22\x0b\xaa\x1f\x49\x8a\xa0\x44\xe5\x44\x83\x7c\xcc\xb0\x50\x59\xc6\x20\xb3\x87\x77\xcf\x8c\x3e\x08\x87\x5c\x19\x73\x9d\x31\x0f\x71\x2c\xaa\x88\x5a\xf6\xb8\x6e\x1b\x2a\x1c\x42\x33\x11\xda\x3f\x8c\x0b\x39\x55\x0e\xcb\xf4\x0d\xcf\xef\x77\x1c\x03\xf5\xbb\x64\xe5\xb8\xa6\x6e\x6f\xce\x24\x56\xd1\x3d\x1d\xb9\xae\x8c\x56\x37\x34\xb8\x59\x9a\xdd\x1c\xcd\x6d\xd5\x17\xba\x91\x31\x2c\x9e\xcb\x56\x40\x5d\x79\xf4\x8a\x3c\x6d\x0c\x28\x08\x38\x3e\x74\x1a\x70\xc0\xd1\x77\xde\x3e\xde\xec\x6e\x8b\xdb\xed\xe2\xbd\xcd\x45\x9e\x3a\x0d\xec\x73\x84\x79\x3b\xba\xd9\x3f\x18\x2c\x3b\x22\x52\xdc\x70\x86\x82\x29\x16\x92\xa4\x2a\x48\x83\xb7\x68\x73\xb0\x22\xc5\xf7\x99\xeb\x75\xb5\x36\x14\xb7\x1d\xc4\xa1\x6d\xbd\x2a\x70\x64\x4d\xf9\x69\x93\xc9\x59\xcd\xcd\x7c\xce\x5a\x2c\x2c\x0c\x4a\x6d\x36\xfb\x82\x62\xe0\x83\x7f\xad\xd0\x5a\x82\x88\xfa\xb3\x04\x91\x22\x89\xd0\x6d\x96\xd8\xdd\xa2\xff\xfe\x31\xa9\x43\xed\x20\xf1\x65\xc9\x43\x43\x17\xdb\x29\x5d\x0b\x2c\xe7\x2a\x8d\x45\x02\x67\x27\xd2\x99\xe1\xe1\xf1\x9a\xd7\x87\x57\x71\xb0\x29\x62\x99\x7e\xa4\xd0\xeb\xeb\x23\xb0\xdc\x58\x26\xd7\x75\x17\x55\x3b\x9b\x90\x31\xf5\xd6\xa6\x38\xd8\x60\x0a\xb3\xc9\xd0\x84\xf2\x99\xfd\x97\xe0\xb6\x74\x4d\xc5\x81\x7c\x11\xaa\x84\x28\x9a\x86\x87\x0b\x6c\x49\xa0\xf8\x77\x6c\x4f\xa3\xde\x28\x3f\xcf\x59\x4b\xef\x45\x13\xe0\xc9\xe1\x05\x76\xcd\x06\xcd\x84\xc6\xd3\xc5\xa6\x09\x5b\xda\xa4\x0a\xfb\x2d\x3e\x5f\x67\xd3\xcb\xf0\x85\x36\x3d\x1c\x96\xa7\xad\xba\x2a\xc8\x37\x0a\x48\x1e\x94\x80\x08\x6c\xf5\x78\x54\x80\x17\x05\xc1\x56\x66\x6c\x20\x49\x75\x69\xd6\x5e\xfc\x1b\x0e\x8b\xe7\xf6\x3a\xad\xc5\x15\x63\x1d\xcb\x8b\xd3\xf5\xc6\x76\x60\x53\xf9\x5a\x9e\xda\x74\x5d\x3c\x3c\x2f\xab\x5d\x83\xf4\x19\xf6\x63\x58\x62\x75\xf9\x80\x7f\xb7\xf5\x03\xb3\x26\xf9\x14\xcb\xc3\x40\x03\xb3\x6a\xa4\x7f\x6f\xf8\x5b\x09\xf6\x86\x07\xc7\x87\x49\xe7\x8f\xf1\xb4\x48\x59\xa5\xd7\xfe\xc4\xfd\x18\xee\xe1\x19\xf2\x26\xfd\x5a\xe7\x3d\x38\x3a\xf2\x27\x2d\xfe\xdc\xe5\x71\x75\x3e\x06\x4b\x22\xae\x7a\xdc\xa0\x96\x95\x2d\x89\x68\xc9\xc7\xb8\x60\x34\xc5\x9f\x42\xe0\x69\x19\xe6\x71\x75\x5e\xcc\x05\xfb\x0d\x7f\x25\x20\xd1\x86\xbb\xc5\x73\x54\x4c\x31\xfa\x9d\x2b\x52\xbb\x2b\x9f\x63\xb0\x3e\xcf\x50\xf9\xd2\x3c\x0a\x75\x66\xac\x77\x87\x4e\xb0\xe3\xed\x41\xb1\xb8\x7d\xd3\xd3\x63\x65\xc1\xc2\x71\xe4\xea\x8e\x85\x57\x66\x89\xa6\xe4\x69\xb6\x1c\xfa\xf0\xe5\x56\x67\x17\xaf\x3e\x23\x81\xb9\xb4\xcf\x6f\xf9\x26\x55\x15\xe3\x34\x4b\x58\xbe\xd8\x7f\x6c\x8c\xff\x08\xdd\xfe\xe3\x8f\xb5\xfc\x2b\x36\xea\xfa\xd8\x71\x25\xc8\xfa\xe6\xdd\x7a\x6d\xa7\xbd\x24\x42\x5e\xb1\xc9\xd6\x60\xfe\x4b\x27\xc9\xa8\x08\x69\xaa\x58\x42\xaf\x99\xab\x0a\xfd\xd9\x5b\xfa\x0a\xb2\x5b\x37\xf5\xe6\x73\x76\xae\x58\x4d\xc2\xab\xe8\x56\x53\x45\xe0\xe8\xdf\x54\x2d\xd0\xed\x94\xb8\xf9\x1f\x3d\x54\xae\xb6\xd1\x61\xdd\xd4\x18\x34\x8b\x1a\xba\xdf\xb9\x92\x80\xca\x63\x29\x03\xe0\xe5\x2f\x51\x01\x68\x8f\x35\x2f\x0f\x10\x9f\xf3\xd0\xdb\x8d\x21\x03\xdf\xc7\x96\xaa\x4a\x45\x9b\xc7\x96\x63\xa4\x0b\xf3\xe9\x6b\xc5\xf9\xdd\xcb\x10\xed\x51\x0a\x65\xfd\x7b\x87\x2a\x03\xb0\x6c\x09\x56\x85\x95\x59\xce\xc2\x74\xcb\x67\xaf\x7e\xb8\xf4\xa5\x2b\x0a\xcd\x87\x56\xe6\x88\xea\x24\xc4\xd6\xe3\x2a\x3b\xe3\x9a\x63\xb2\x5a\x7e\x46\x4c\xfe\x43\x8a\x0a\x8e\xc7\xaf\x06\x77\xc5\xf3\x57\xfa\xc1\xf6\x17\xe4\x59\x4e\x78\x77\xb4\xd5\x05\x8f\xcf\xae\x3d\x9c\x3d\xf0\x59\xa8\xab\x03\x47\xf3\x23\x62\xcd\xb2\x5d\x85\x7f\x2e\xd9\xba\xb8\x9a\x6f\x30\x62\x4a\xd4\x9a\x64\xb7\x6d\x2f\xd0\x7c\x82\x62\x64\xbf\xf3\xe5\xfd\xfe\x72\x83\xbd\x95\x95\xec\xf2\x1a\xf0\x9f\xf1\x37\x4a\xc6\xb0\x18\x95\xbe\x68\xdd\x32\x8d\x3d\xb4\x14\xd2\xc2\xdf\x1e\x28\xf2\x89\x4a\xbc\xe9\x99\xce\xd7\xb5\xf4\x26\x70\x6c\x8b\x3e\x17\x95\xec\x9f\xc8\x46\x9e\xed\x6e\x17\x0f\x4d\x85\xec\x17\x58\x58\x71\xbb\xdd\x28\x86\x43\xc8\x34\x39\x90\xf0\x74\x55\xd4\x2b\x74\x02\x87\xbc\x84\x15\xc7\x5f\x51\xc0\xef\x8a\x67\x6b\x2e\x95\xe1\xb4\x15\x19\xaa\x6f\x99\x17\x3b\x1e\xb9\xa8\x94\xf9\xb2\x4b\xf3\x68\xec\xe5\x29\x51\x2f\xb2\x20\xc2\x95\xf6\x6a\x57\xf0\x37\x88\x50\x32\x77\x93\xce\x2d\x8c\x34\xfd\x08\x8b\x67\x40\xad\x2e\x35\x4e\x99\xde\x42\xc4\x3f\xae\xac\xeb\x68\xab\x2b\xd7\x71\xd7\x56\xae\xfb\x7a\xa9\x10\x5c\x54\xbb\x7a\x6d\x8b\x1d\x0e\xe1\xc3\x8f\x6f\x7f\x1c\xeb\x9a\x11\xe4\x43\x4c\x43\x70\xc7\x2d\x4e\x8d\x5f\x3f\xdb\xf0\xe5\xf1\x9f\xff\xbc\xe2\x74\x98\xff\xb4\xe0\x74\x18\xab\x75\x32\xff\xff\x01\x00\x6e\x16\x28\x12\x1c\x53\x00\x00")
 
 func templatesIndexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -90,12 +132,12 @@ func templatesIndexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "templates/index.html", size: 3727, mode: os.FileMode(420), modTime: time.Unix(1485277294, 0)}
+	info := bindataFileInfo{name: "templates/index.html", size: 21276, mode: os.FileMode(420), modTime: time.Unix(1792221956, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
 
-var _staticCssDashboardCss = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\x9c\x94\xcb\x6e\xeb\x36\x10\x86\xf7\x7e\x0a\x42\xd9\x05\x92\x23\xc9\xba\x38\xca\xaa\x6d\x10\xb4\x8b\xb6\x40\x8d\x3c\x00\x45\x8e\x64\x22\x14\x29\x90\xb4\x63\xb7\xe8\xbb\x1f\xe8\x62\x93\x72\xc2\x1c\x20\xe0\x22\x9f\x26\xfc\xff\xe1\xcc\x90\x5e\x13\xc9\x23\xde\x86\x68\x86\x28\x71\x30\x76\xd8\x8d\xa7\x96\x1d\xdc\x58\xcc\x2c\xe6\x16\x0b\x8b\xa5\xc5\xad\xc5\x47\xf4\xdf\x0a\x21\x84\x7a\x4c\x29\x13\x6d\x64\x64\x5f\xa1\x75\xae\xa0\x7b\x5a\xc4\x6b\x69\x8c\xec\xae\xff\xfa\x7f\xb5\x5a\x8d\x16\xba\x9b\xbd\x74\x77\xad\x63\xc0\xd8\x61\x37\x9e\x5a\x76\x70\x63\x31\xb3\x98\x5b\x2c\x2c\x96\x16\xb7\x16\xbf\x5f\xc7\xe8\x70\xd2\xb3\xd5\x49\x5f\xcb\x18\x30\x76\xd8\x8d\xa7\x96\x1d\xdc\x58\xcc\x2c\xe6\x16\x0b\x8b\xa5\xc5\xad\xc5\xef\x97\xf1\x70\x8f\x9e\xb1\xde\xd7\x12\x2b\x8a\x76\xe6\xcc\x41\xa3\xfb\x87\xd5\xaa\x96\xf4\x3c\x9b\x36\x52\x98\xa8\xc1\x1d\xe3\xe7\x0a\x45\xb8\xef\x39\x44\xfa\xac\x0d\x74\xe1\xaf\x9c\x89\xb7\x3f\x31\xd9\x8d\x9f\x2f\x52\x98\x30\xd8\x41\x2b\x01\xbd\xfe\x11\x84\xc1\x3f\xb2\x96\x46\x06\x61\xf0\xf7\xe9\xdc\x82\x08\xc2\xe0\xb5\x3e\x08\x73\x08\xc2\xe0\x37\x2c\x0c\x56\xc0\x79\x10\x06\x2f\x4c\x61\xb4\xc3\x42\x07\x61\xf0\xac\x24\xa3\x97\x8f\xdf\x81\x1f\xc1\x30\x82\xd1\x5f\x70\x80\x20\xfc\x45\x31\xcc\x43\x8d\x85\x8e\x34\x28\xd6\x4c\xc5\xd5\x98\xbc\xb5\x4a\x1e\x04\x8d\x88\xe4\x52\x55\xe8\xae\x29\x87\x35\xcd\xa9\x96\xa7\xb9\x90\x5a\x2a\x0a\xaa\x42\x49\x7f\x42\x5a\x72\x46\xd1\x5d\x93\x0c\xcb\xef\x43\x86\x65\x7d\xd6\x86\x19\x0e\x21\x9a\x3e\xde\x19\x6d\xc1\xcc\xe6\x1d\x56\x2d\x13\x15\xc2\x07\x23\x6f\x05\xf3\x96\x8b\x6b\x86\x87\x35\x25\x35\x70\x32\x11\xe6\xac\x15\x15\x22\x20\x0c\xa8\x27\xc7\xee\x3a\xb0\xbc\x3f\x2d\xe2\xe3\x80\x93\xf8\x12\x1d\x47\xf4\x0e\xac\xdd\x9b\x0a\x15\x71\xec\x44\x35\xfb\x17\x2a\x94\xa4\x97\xad\x63\x42\xa3\xb0\xd0\x8d\x54\x5d\x85\x0e\x7d\x0f\x8a\x60\x0d\xce\xa1\x17\x85\xed\x67\xdb\x64\x33\x1e\xc2\x56\x36\x18\x2d\x76\x52\xa6\x7b\x8e\xcf\x15\x6a\x38\xcc\xd9\xc6\xca\x22\x66\xa0\xd3\x53\x38\x02\x41\x3d\x26\xeb\x23\xe6\x87\xdb\x5e\x25\x4d\x59\xd6\xd9\x87\x7a\xb2\x62\x51\xcf\x27\x0d\x1c\x92\x55\x28\x99\x72\x71\x68\x41\xd0\x9b\x49\x0d\xed\x9b\xc7\xf5\x95\xd1\xb5\xfe\x64\xd1\xed\xb9\xaf\xd7\x20\x67\x02\xa2\x8f\x7b\x7f\xd2\xee\xf9\x60\x94\x1d\x6f\x7b\xc8\xc4\xe8\x58\x73\x49\xde\x26\xab\x23\xa8\xe1\x2d\xf0\xcb\x21\xa7\xbb\xf1\xb4\x68\x57\x99\x0f\xeb\x93\x4b\x91\x0f\x97\xc2\xc9\x38\xff\x8d\xec\xeb\x78\x67\xd4\xec\xdd\xa3\x2f\x8b\xf9\x44\x3b\x8c\x75\xf9\xc3\x53\xa1\x18\x65\xcb\xdd\x43\x86\x35\xc1\x06\x5a\xa9\xce\x51\x7c\x79\x8a\x1f\xdf\xda\x65\xd2\x3e\x69\xe2\x97\x36\x4d\xd9\xc4\xe0\x97\xa6\x7e\x69\x4a\x70\x9c\x12\xbf\x74\xe3\x97\xd2\x22\x2d\xd3\xad\x5f\x9a\xf9\xa5\x8f\x59\x51\xd6\xd4\x2f\xcd\xfd\xd2\x2d\xc9\x8b\xac\xf6\x4b\x0b\xbf\x14\x36\x65\x49\x52\xbf\xb4\xf4\x4b\xc7\xdf\xd3\xc6\x2f\xdd\xfa\xa5\x35\xa9\x69\xfa\x45\xd6\xc7\x2f\xae\x44\x59\x03\x19\xb2\xfe\x08\x00\x00\xff\xff\xa6\xe3\xc9\xf0\xfc\x08\x00\x00")
+var _staticCssDashboardCss = []byte("\x1f\x8b\x08\x00\x00\x09\x6e\x88\x00\xff\xbc\x58\x5b\x8f\xbc\xb6\x15\x7f\xdf\x4f\x61\x11\xe5\x25\x02\xc2\xdc\x77\x59\x29\x52\x73\x53\xfb\x90\x56\xea\x5f\x79\xeb\x8b\xc1\x07\xc6\x5d\x63\x23\x1f\xcf\x0e\xd3\x55\xbe\x7b\x05\x03\x06\x3c\x78\x66\xdb\x44\xd1\x3c\x8c\x31\xfe\x1d\x9f\x9f\xcf\x15\xc7\xb9\x12\x91\x28\x43\xd2\x0f\xa2\xd5\x64\x98\x4c\xc6\xd3\xf9\xf5\x38\x9e\x0c\x37\xe3\x70\x3b\x0e\x77\xe3\x70\x3f\x0e\x0f\xe3\xf0\x79\x1c\xbe\x90\x8f\x27\x42\x08\xa9\x29\x63\x5c\x96\x91\x51\x75\x4a\xe2\x9d\x86\xea\x75\x36\x9f\x29\x63\x54\x65\x5f\xfd\xf6\xf4\xf4\xd4\x89\xc0\xaa\x97\x85\x95\xe5\x81\xd5\xc8\x03\xab\x91\x07\x56\x23\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x2c\x0f\xac\x7e\x07\x8f\x4e\x42\x83\xbd\xa8\x06\x2d\x8d\x06\x47\x1a\x0d\x8e\x34\x1a\x1c\x69\x34\x68\x69\x34\x68\x69\x34\x68\x69\x34\x68\x69\x34\x68\x69\x34\x68\x69\x34\x68\x69\x34\xf8\x3b\x68\x7c\xfb\x0d\xf9\x91\xe2\x31\x53\x54\x33\xf2\xc5\x5c\x04\x20\xf9\xe6\xdb\xa7\xa7\x98\x0a\xd0\x06\x7b\xb9\x8c\x63\x2d\xe8\x25\x25\x52\x49\xb8\x5a\xb8\xa2\xba\xe4\xd2\xb7\x53\x4a\x9e\xeb\x86\xac\xd6\x75\x73\x5d\x9d\xd1\xfc\xad\xd4\xea\x24\x59\x94\x2b\xa1\x74\x4a\xbe\x62\xfb\xf5\x61\xfd\x7c\x7d\x3d\xcc\x15\x45\x71\x9d\x28\x94\x34\x11\xf2\xff\x40\x4a\x56\xdb\x41\x48\x37\x79\x06\x5e\x1e\x4d\x4a\x76\x49\xd2\x11\x18\x14\xbd\xfe\xcf\xcf\x21\x25\xeb\xba\x21\xd7\x75\x99\x62\x97\xfe\x6d\x27\xa7\xa0\x15\x17\x97\x94\x44\xb4\xae\x05\x44\x78\x41\x03\x55\xf8\xbd\xe0\xf2\xed\x17\x9a\x7f\xe9\x1e\x7f\x56\xd2\x84\xc1\x17\x28\x15\x90\x5f\xff\x16\x84\xc1\x3f\x55\xa6\x8c\x0a\xc2\xe0\x1f\xcd\xa5\x04\x19\x84\xc1\xaf\xd9\x49\x9a\x53\x10\x06\x3f\x50\x69\xa8\x06\x21\x82\x30\xf8\x99\x6b\x4a\xbe\x50\x89\x41\x18\xfc\xa8\x15\x67\xc3\xc3\x5f\x41\xbc\x83\xe1\x39\x25\x7f\x87\x13\x04\xe1\x5f\x34\xa7\x22\x44\x2a\x31\x42\xd0\xbc\xf0\x1e\x56\x71\x68\x7f\x1d\x91\x38\x53\x4d\x4f\x24\x53\x9a\x81\x4e\xc9\xaa\x6e\x08\x2a\xc1\x19\xf9\xaa\x58\xb5\x3f\xbf\x9c\xbc\xc8\x8b\xdc\xca\xb9\x1e\x1a\x97\xe5\x4c\xe0\xa2\x89\x32\xd5\x44\x78\xa4\x4c\x9d\x53\x92\x90\x84\x6c\xeb\x66\x5c\x30\x57\xab\x56\xc8\x0d\x57\x32\x25\x1a\x04\x35\xfc\x1d\xc6\x15\x31\x34\xb5\xd2\xc6\xef\x57\x23\x98\x66\xa8\xc4\xc9\xf4\xf3\x9d\xa3\xed\x07\x57\xd0\x57\x27\x78\xae\x9b\x5b\x7f\x49\x86\x49\x03\x8d\x89\x8c\xa6\x12\x0b\xa5\xab\x94\x9c\xea\x1a\x74\x4e\x71\x54\x27\x3d\xaa\x77\xd0\x3e\xa5\x32\xa1\xf2\xb7\x5b\xd5\x69\xbf\x6e\x38\xa5\xc3\xae\xfd\x4d\xb6\x64\x90\x2b\x4d\xaf\x24\x6e\x02\x46\x40\x61\xd2\xf6\xf4\x16\x04\xf7\xea\xcc\xc5\xaf\x8a\xc3\x21\xdb\x4e\x56\x1b\x6e\x04\x84\xa4\xd5\x9f\xc4\x67\xce\x4a\x18\x34\xbf\x6e\x91\x12\x7a\x32\xca\x05\x38\x4a\x6f\x69\xfb\x9b\x28\x4d\x05\x2f\x65\x4a\x72\x90\x06\xf4\x4c\xe3\x21\x69\xec\xea\x66\x36\xdf\x59\x64\x95\x2c\x46\xe7\x3e\x49\x26\xb3\xbd\x61\xd6\x9f\x37\x8c\x43\xec\xd8\x8b\x5d\x6d\x76\xb3\x83\xeb\x34\x9f\xad\xb4\xc6\x2b\x04\xf4\xbb\x75\xcc\x22\x6e\xa0\xc2\xeb\x74\x04\x92\x79\x84\xc4\xef\x54\x9c\xc0\x63\x01\x87\xcf\xd6\x7a\xa3\xef\x00\xdb\xcd\x52\xb2\xb2\x7b\xc5\x68\xa8\x80\x81\x5b\x48\xa6\x73\x9d\x2a\x25\x3d\x95\x10\x61\x45\x85\x88\xa5\x8a\x18\x35\x83\xb3\xa9\x9a\xe6\xdc\x5c\x52\x12\x6f\x26\xaa\x2f\xad\xef\x27\x05\xcd\x40\xe0\x9c\x90\x3d\x9c\xab\x57\x2e\x9d\xc0\x28\x66\xe9\x24\xb2\x2c\xbb\x39\x86\xb5\xf5\x65\x01\x25\x48\x76\x57\xe1\x23\x50\x61\x8e\x8e\xd4\x69\x9a\x99\x08\x5e\xad\x1e\x9d\xef\xe0\xef\x49\x17\xf4\x7d\xe4\xdb\xbd\xd0\x50\x73\xc2\xc1\xb2\xbd\x56\xef\xa0\x0b\xa1\xce\xd1\xe5\x26\x4a\xe6\xcb\x0d\xcd\x6c\xcc\x9c\x39\x33\xc7\x94\xac\x92\xe4\xeb\x57\x27\x4d\x0a\x5a\x23\xa4\x64\x18\xdd\x72\xd8\x0c\x1c\xdc\xc0\xf3\xed\xcb\xdc\x12\xb6\xa9\x9b\xb6\x98\x7a\x11\xdd\xa3\x6b\xa8\x75\x4e\x93\x75\x7e\x2f\x30\x3f\x13\x83\xce\x46\x4c\x9d\xe5\xf2\x76\x6e\x1d\x70\x91\x82\x1a\x90\xf9\x25\x24\x8b\x6f\x11\x40\x3e\xca\xa9\xbd\xf5\xbb\xbc\xef\xec\xf3\x30\x01\xb4\x61\x18\x31\xae\x21\xbf\xe6\xe4\x5c\x89\x53\x25\x3d\x52\x66\x7e\x6f\x03\xf8\x33\xa9\x65\xea\x99\xff\x3e\xa1\xe1\xc5\x25\xca\x95\x34\x20\xcd\xfc\xe5\x83\xbc\xb2\xd9\xd7\xcd\x5d\xe5\xe2\x33\xd5\x72\x2c\xda\x83\xb8\xa2\x38\x14\x09\xdc\x47\xe6\x9a\xb7\xed\x87\xf8\x8c\xfd\xfe\x80\x8c\x70\xab\x06\xd6\x54\xbf\x09\x2e\xc1\xc9\xec\xdb\x79\xec\x1e\x39\x1a\x55\x6a\x5a\x45\x8b\x75\x60\x7d\x78\xb0\x3c\xce\xa8\xc6\x90\xf8\xde\xb6\xae\x0e\xd2\xf0\xb6\xdb\x9d\x4b\xde\x25\x5f\x4f\xe4\x76\x59\xe0\xd3\x19\x64\xb6\xfa\xcf\x4b\x20\xf3\x6d\x8f\x21\x59\x9a\xf6\xa7\x95\x47\x21\x36\x93\x13\x4b\x5a\x0d\xac\xa6\xa0\xb6\xab\xf1\xab\x44\x3e\x6e\x13\xd1\x6e\x48\x44\xf9\x49\x63\x4b\xac\x56\x7c\x0c\x91\xf3\x91\x1b\x88\xb0\xa6\x39\xb4\x3d\xd4\x59\xd3\xda\x2f\x3e\xa6\x98\xa7\xb4\x30\x93\xd6\xa9\x8f\xba\x80\xfc\x6b\xbd\xfb\x7e\x1b\xdc\xc1\x32\xb8\x0f\xfe\xc9\x0f\x66\x36\x34\x16\x62\xc2\x8b\x99\x07\xef\xd8\xa3\xb7\xdd\x79\x51\x6c\x72\x76\x07\xeb\x84\xef\x1c\xfc\xcc\x0e\x8c\xbe\xce\x34\x99\xc6\xf5\xac\x3a\x0f\x95\xb3\x2d\x99\xbd\x1b\xbb\x26\x9d\x66\xac\x21\x3a\xc6\x92\xbc\x58\xa7\xdb\xb8\x8e\x6e\xd7\x3e\x28\x35\xbd\x62\x8c\xbf\x93\x8f\x79\xa2\xe5\xb2\x93\xd8\xf7\xe1\xad\xa8\x77\xd0\x5d\xfe\x1a\x94\xbc\xf6\xa6\xaf\xde\xda\x71\xeb\x72\x93\x1d\xfb\xff\x68\xfc\x72\x19\x02\xd5\xaa\x3e\x27\xb3\x80\x6d\xdb\x4a\x37\xb4\x92\xb1\xbd\x1f\x77\x88\x73\x6a\xa0\x54\xfa\x12\x25\x37\xd6\x8b\xdc\x8a\xe0\x83\xae\xfc\xd0\x69\xf6\x5f\x82\xae\xfd\xd0\xa1\x59\xf0\x41\x37\x7e\xe8\xad\x83\xcd\xa1\x5b\x3f\xf4\x65\xbb\x3f\x64\xcc\x0f\xdd\xf9\xa1\xcf\xf9\x6e\xbf\xcd\xfc\xd0\xbd\x1f\x0a\x9b\xc3\x21\x5f\xfb\xa1\x07\x3f\xb4\xfb\x04\x2f\xfc\xd0\x67\x3f\x34\xcb\x33\xb6\xbe\xb3\xeb\x8b\x1f\xba\x3a\x64\x90\x77\xbb\xb6\x29\x21\x66\xc3\x65\x0d\x92\xd3\x90\x06\x04\x47\x13\x61\x7b\x77\x33\xfd\xde\x9c\xb8\xe3\x7a\x5a\x2d\xa7\x22\x04\x27\x1f\xf3\xc5\xfb\xeb\x85\x89\x1b\xe2\x7b\x8f\x00\x37\xf5\x4d\x5b\x1a\xcf\xb7\x70\x7b\x7c\x92\xbe\x2f\x14\x91\xbe\xf2\xdc\xbb\xc8\x9a\xea\xb4\x1e\x74\x6a\xa5\xd1\x90\xc4\x35\x97\x12\xd8\xff\xab\x53\x8f\x76\x52\xd0\x1f\x78\xe1\x35\xdc\xbd\x7c\xe2\xe6\x66\x4a\x73\xda\x4c\xb5\x37\x05\x42\x69\x20\xb1\xd1\xd0\xdf\x01\xcc\xe7\x1e\x79\x85\xfd\x64\x72\xb4\x6f\xaf\x74\x92\xde\xce\x37\x77\x64\xbf\x80\x14\x2a\x24\x3f\x28\x89\x4a\x50\x0c\x49\xa5\xa4\xea\x8a\xf3\x1d\xab\xdc\xaa\x76\x93\x26\x57\x7b\x7b\x66\x2e\x42\x70\xff\x55\xde\x9c\xf5\x1b\x5c\x1c\x83\x3b\x1d\xd2\xb8\x34\xd3\x54\xe6\x47\xf2\xdd\x0c\xe4\xb6\x1f\xf7\x51\x69\x06\x45\x3b\xeb\xf6\x0a\x6d\xab\xf0\x4c\x02\x2f\xbc\x7d\xa6\x92\x01\xfb\x84\x9c\x9f\x16\xe5\x98\x4b\x3d\x2e\xef\x53\xe8\xcb\x8b\x63\xd5\xd9\x97\xe2\x88\x5d\xea\xdc\x67\x61\xd1\x16\xe7\xfe\xda\xcd\xf5\x5c\x12\xef\xd0\x27\x32\xce\x8f\x54\x96\x36\x6a\x96\x9c\xbe\x28\x36\xd9\x92\xd9\x6a\x2e\x91\x64\x27\x63\xd4\xf0\xed\xd7\x07\x58\xdb\x4a\x4e\x6e\xfa\xfe\xd7\x9b\x3d\x4f\x74\x31\xc6\x5e\x1d\x1d\xa7\xf7\xcd\x8b\x4d\xa8\x93\x04\x5c\x0a\x82\xf7\xf7\x75\xdf\x2d\xb1\xb1\x60\x2e\x05\x97\xf0\xfa\xf4\xdb\xd3\x7f\x07\x00\xd0\x91\x64\x8c\xc2\x19\x00\x00")
 
 func staticCssDashboardCssBytes() ([]byte, error) {
 	return bindataRead(
@@ -110,7 +152,7 @@ func staticCssDashboardCss() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "static/css/dashboard.css", size: 2300, mode: os.FileMode(420), modTime: time.Unix(1485196034, 0)}
+	info := bindataFileInfo{name: "static/css/dashboard.css", size: 6594, mode: os.FileMode(436), modTime: time.Unix(1792220096, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -287,6 +329,8 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
+	"templates/dashboards.html":      templatesDashboardsHtml,
+	"templates/explore.html":         templatesExploreHtml,
 	"templates/index.html":           templatesIndexHtml,
 	"static/css/dashboard.css":       staticCssDashboardCss,
 	"static/css/epoch.min.css":       staticCssEpochMinCss,
@@ -352,7 +396,9 @@ var _bintree = &bintree{nil, map[string]*bintree{
 		}},
 	}},
 	"templates": &bintree{nil, map[string]*bintree{
-		"index.html": &bintree{templatesIndexHtml, map[string]*bintree{}},
+		"dashboards.html": &bintree{templatesDashboardsHtml, map[string]*bintree{}},
+		"explore.html":    &bintree{templatesExploreHtml, map[string]*bintree{}},
+		"index.html":      &bintree{templatesIndexHtml, map[string]*bintree{}},
 	}},
 }}
 
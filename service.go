@@ -4,14 +4,27 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
+)
+
+const (
+	ExpvarFormat     = "expvar"
+	PrometheusFormat = "prometheus"
 )
 
 type Service struct {
-	Name string
-	URL  url.URL
+	Name    string
+	URL     url.URL
+	Format  string
+	Timeout time.Duration
+	Fetcher Fetcher
 }
 
 func ParseURL(rawurl string) (*url.URL, error) {
+	return parseURL(rawurl, "/debug/vars")
+}
+
+func parseURL(rawurl string, path string) (*url.URL, error) {
 	if !strings.HasPrefix(rawurl, "http") {
 		rawurl = fmt.Sprintf("http://%s", rawurl)
 	}
@@ -21,7 +34,7 @@ func ParseURL(rawurl string) (*url.URL, error) {
 		return nil, err
 	}
 	if url.Path == "" {
-		url.Path = "/debug/vars"
+		url.Path = path
 	}
 	return url, nil
 }
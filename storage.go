@@ -0,0 +1,259 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// segmentDuration is how long values are appended to a segment file before
+// a new one is started.
+const segmentDuration = time.Hour
+
+// Record holds the values of all line-chart series sampled by one crawl,
+// keyed by LineChart.Key. The series of wildcard metrics are named by the
+// labels under the same key.
+type Record struct {
+	Time   int64                 `json:"t"`
+	Series map[string][]*float64 `json:"s"`
+	Labels map[string][]string   `json:"l,omitempty"`
+}
+
+// Storage keeps the crawled values in append-only segment files, one JSON
+// record per line, named after the time their first record was written.
+// Segments older than the retention are compacted away.
+type Storage struct {
+	mu        sync.Mutex
+	dir       string
+	retention time.Duration
+	file      *os.File
+	start     time.Time
+}
+
+func OpenStorage(dir string, retention time.Duration) (*Storage, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	s := &Storage{
+		dir:       dir,
+		retention: retention,
+	}
+	if err := s.Compact(Now()); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Append writes the record to the current segment, starting a new segment
+// when the current one is full.
+func (s *Storage) Append(r *Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Unix(r.Time, 0)
+
+	if s.file == nil || now.Sub(s.start) >= segmentDuration {
+		if err := s.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.file.Write(append(data, '\n'))
+	return err
+}
+
+func (s *Storage) rotate(now time.Time) error {
+	if s.file != nil {
+		if err := s.file.Close(); err != nil {
+			return err
+		}
+		s.file = nil
+	}
+
+	if err := s.compact(now); err != nil {
+		return err
+	}
+
+	name := filepath.Join(s.dir, fmt.Sprintf("%d.log", now.Unix()))
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	s.file, s.start = f, now
+	return nil
+}
+
+// Load reads the records written since the given time, oldest first.
+// Records that can't be parsed, e.g. a line cut short by a crash, are
+// skipped.
+func (s *Storage) Load(since time.Time) ([]*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	segments, err := s.segments()
+	if err != nil {
+		return nil, err
+	}
+
+	records := []*Record{}
+	for i, seg := range segments {
+		if i+1 < len(segments) && segments[i+1].start <= since.Unix() {
+			continue
+		}
+
+		rr, err := readSegment(seg.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range rr {
+			if r.Time >= since.Unix() {
+				records = append(records, r)
+			}
+		}
+	}
+	return records, nil
+}
+
+// Compact removes the segments that only hold records older than the
+// retention, and rewrites the one that holds both old and recent records.
+func (s *Storage) Compact(now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.compact(now)
+}
+
+func (s *Storage) compact(now time.Time) error {
+	segments, err := s.segments()
+	if err != nil {
+		return err
+	}
+
+	cutoff := now.Add(-s.retention).Unix()
+	for i, seg := range segments {
+		if s.file != nil && seg.path == s.file.Name() {
+			break
+		}
+		if seg.start >= cutoff {
+			break
+		}
+
+		if i+1 < len(segments) && segments[i+1].start <= cutoff {
+			if err := os.Remove(seg.path); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := compactSegment(seg.path, cutoff); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Storage) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+	return s.file.Close()
+}
+
+type segment struct {
+	path  string
+	start int64
+}
+
+type byStart []segment
+
+func (s byStart) Len() int           { return len(s) }
+func (s byStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s byStart) Less(i, j int) bool { return s[i].start < s[j].start }
+
+func (s *Storage) segments() ([]segment, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.log"))
+	if err != nil {
+		return nil, err
+	}
+
+	segments := []segment{}
+	for _, f := range files {
+		start, err := strconv.ParseInt(strings.TrimSuffix(filepath.Base(f), ".log"), 10, 64)
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{path: f, start: start})
+	}
+	sort.Sort(byStart(segments))
+	return segments, nil
+}
+
+func readSegment(path string) ([]*Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	records := []*Record{}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, &r)
+	}
+	return records, scanner.Err()
+}
+
+// compactSegment rewrites the segment without the records older than the
+// cutoff.
+func compactSegment(path string, cutoff int64) error {
+	records, err := readSegment(path)
+	if err != nil {
+		return err
+	}
+
+	buf := []byte{}
+	for _, r := range records {
+		if r.Time < cutoff {
+			continue
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		buf = append(append(buf, data...), '\n')
+	}
+
+	if len(buf) == 0 {
+		return os.Remove(path)
+	}
+
+	tmp := path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buf, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/antonholmquist/jason"
+	"github.com/stretchr/testify/assert"
+)
+
+func tempStorage(t *testing.T, retention time.Duration) (*Storage, func()) {
+	dir, err := ioutil.TempDir("", "storage")
+	assert.NoError(t, err)
+
+	s, err := OpenStorage(dir, retention)
+	assert.NoError(t, err)
+
+	return s, func() {
+		s.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func testRecord(t int64, v float64) *Record {
+	return &Record{
+		Time:   t,
+		Series: map[string][]*float64{"memstats.Alloc|service1": {floatPtr(v), nil}},
+	}
+}
+
+func TestStorage_AppendLoad(t *testing.T) {
+	s, cleanup := tempStorage(t, 24*time.Hour)
+	defer cleanup()
+
+	start := time.Now().Add(-3 * time.Hour).Unix()
+	for i := int64(0); i < 6; i++ {
+		assert.NoError(t, s.Append(testRecord(start+i*1800, float64(i))))
+	}
+
+	segments, err := s.segments()
+	assert.NoError(t, err)
+	assert.Len(t, segments, 3)
+
+	records, err := s.Load(time.Unix(start+2*1800, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Record{
+		testRecord(start+2*1800, 2),
+		testRecord(start+3*1800, 3),
+		testRecord(start+4*1800, 4),
+		testRecord(start+5*1800, 5),
+	}, records)
+}
+
+func TestStorage_Load_Truncated(t *testing.T) {
+	s, cleanup := tempStorage(t, 24*time.Hour)
+	defer cleanup()
+
+	now := time.Now().Unix()
+	assert.NoError(t, s.Append(testRecord(now, 1)))
+	_, err := s.file.WriteString(`{"t":` + "\n")
+	assert.NoError(t, err)
+	assert.NoError(t, s.Append(testRecord(now+1, 2)))
+
+	records, err := s.Load(time.Unix(now, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, []*Record{testRecord(now, 1), testRecord(now+1, 2)}, records)
+}
+
+func TestStorage_Compact(t *testing.T) {
+	s, cleanup := tempStorage(t, 2*time.Hour)
+	defer cleanup()
+
+	start := time.Unix(1000000, 0)
+	for i := int64(0); i < 8; i++ {
+		assert.NoError(t, s.Append(testRecord(start.Unix()+i*1800, float64(i))))
+	}
+	s.Close()
+	s.file = nil
+
+	assert.NoError(t, s.Compact(start.Add(3*time.Hour+15*time.Minute)))
+
+	segments, err := s.segments()
+	assert.NoError(t, err)
+	assert.Len(t, segments, 3)
+
+	records, err := s.Load(start)
+	assert.NoError(t, err)
+	assert.Equal(t, []*Record{
+		testRecord(start.Unix()+3*1800, 3),
+		testRecord(start.Unix()+4*1800, 4),
+		testRecord(start.Unix()+5*1800, 5),
+		testRecord(start.Unix()+6*1800, 6),
+		testRecord(start.Unix()+7*1800, 7),
+	}, records)
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "*"))
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+}
+
+func TestCrawler_StoreRestore(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	s, cleanup := tempStorage(t, 24*time.Hour)
+	defer cleanup()
+
+	o, err := jason.NewObjectFromBytes([]byte(`{"memstats": {"alloc": 123}}`))
+	assert.NoError(t, err)
+
+	crawler := &Crawler{
+		services: []*Service{{Name: "service1"}, {Name: "service2"}},
+		widgets: &Widgets{
+			LineCharts: []*LineChart{
+				{cid: "lc1", Metric: NewSafeMetric("memstats.alloc")},
+			},
+		},
+		storage: s,
+	}
+	crawler.store(crawler.ExtractUpdates(map[string]*Expvars{"service1": {o}}))
+
+	// the restarted process has a different layout
+	restarted := &Crawler{
+		services: crawler.services,
+		widgets: &Widgets{
+			LineCharts: []*LineChart{
+				{cid: "lc1", Metric: NewSafeMetric("memstats.sys")},
+				{cid: "lc2", Metric: NewSafeMetric("memstats.alloc")},
+			},
+		},
+		history: NewHistory(time.Hour, time.Second),
+		storage: s,
+	}
+	restarted.Restore(Now().Add(-time.Hour))
+
+	assert.Equal(t, []*LineChartHistory{
+		{
+			ID: "lc2",
+			Series: [][]LinePoint{
+				{{Time: 1000000, Y: floatPtr(123)}},
+				{{Time: 1000000, Y: nil}},
+			},
+		},
+	}, restarted.history.Snapshot().History)
+}
+
+func TestCrawler_StoreRestore_Wildcard(t *testing.T) {
+	Now = func() time.Time {
+		return time.Unix(1000000, 0)
+	}
+
+	defer func() {
+		Now = time.Now
+	}()
+
+	s, cleanup := tempStorage(t, 24*time.Hour)
+	defer cleanup()
+
+	o, err := jason.NewObjectFromBytes([]byte(`{"handlers": {"users": {"count": 1}, "orders": {"count": 2}}}`))
+	assert.NoError(t, err)
+
+	newCrawler := func() *Crawler {
+		return &Crawler{
+			services: []*Service{{Name: "service1"}},
+			widgets: &Widgets{
+				LineCharts: []*LineChart{
+					{cid: "lc1", Metric: NewSafeMetric("handlers.*.count")},
+				},
+			},
+			history: NewHistory(time.Hour, time.Second),
+			storage: s,
+		}
+	}
+
+	crawler := newCrawler()
+	crawler.store(crawler.ExtractUpdates(map[string]*Expvars{"service1": {o}}))
+
+	// the restarted process has already seen a series the stored values
+	// don't have
+	restarted := newCrawler()
+	restarted.widgets.LineCharts[0].Observe([]string{"admin", "users"})
+	restarted.Restore(Now().Add(-time.Hour))
+
+	assert.Equal(t, []string{"admin", "users", "orders"}, restarted.widgets.LineCharts[0].Series(nil))
+	assert.Equal(t, []*LineChartHistory{
+		{
+			ID: "lc1",
+			Series: [][]LinePoint{
+				{{Time: 1000000, Y: nil}},
+				{{Time: 1000000, Y: floatPtr(1)}},
+				{{Time: 1000000, Y: floatPtr(2)}},
+			},
+			Labels: []string{"admin", "users", "orders"},
+		},
+	}, restarted.history.Snapshot().History)
+}
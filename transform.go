@@ -0,0 +1,95 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DeltaTransform      = "delta"
+	RateTransform       = "rate"
+	DerivativeTransform = "derivative"
+)
+
+type sample struct {
+	value float64
+	time  time.Time
+}
+
+// Transform turns consecutive samples of a metric into the change between
+// them. The previous sample is remembered separately for every service.
+type Transform struct {
+	Kind string
+	prev map[string]sample
+}
+
+func NewTransform(kind string) (*Transform, error) {
+	switch kind {
+	case "":
+		return nil, nil
+	case DeltaTransform, RateTransform, DerivativeTransform:
+		return &Transform{
+			Kind: kind,
+			prev: map[string]sample{},
+		}, nil
+	default:
+		return nil, fmt.Errorf("Unknown transform: %s", kind)
+	}
+}
+
+// Apply records the value read for the service and returns the transformed
+// value. Nil is returned until two consecutive numeric samples are known.
+// A nil transform returns the value as it is.
+func (t *Transform) Apply(service string, v interface{}, now time.Time) interface{} {
+	if t == nil {
+		return v
+	}
+
+	var value float64
+	switch n := v.(type) {
+	case int64:
+		value = float64(n)
+	case float64:
+		value = n
+	default:
+		return nil
+	}
+
+	prev, ok := t.prev[service]
+	t.prev[service] = sample{value: value, time: now}
+	if !ok {
+		return nil
+	}
+
+	delta := value - prev.value
+	seconds := now.Sub(prev.time).Seconds()
+
+	switch t.Kind {
+	case DeltaTransform:
+		if _, ok := v.(int64); ok {
+			return int64(delta)
+		}
+		return delta
+	case RateTransform:
+		if seconds <= 0 {
+			return nil
+		}
+		return delta / seconds
+	case DerivativeTransform:
+		if seconds <= 0 {
+			return nil
+		}
+		// a counter that went down has been reset, so everything it
+		// counted since then is the increase
+		if delta < 0 {
+			delta = value
+		}
+		return delta / seconds
+	}
+
+	return nil
+}
+
+func (t *Transform) String() string {
+	return t.Kind
+}
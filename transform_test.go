@@ -0,0 +1,91 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTransform(t *testing.T) {
+	tr, err := NewTransform("")
+	assert.NoError(t, err)
+	assert.Nil(t, tr)
+
+	tr, err = NewTransform("rate")
+	assert.NoError(t, err)
+	assert.Equal(t, RateTransform, tr.Kind)
+
+	_, err = NewTransform("integral")
+	assert.EqualError(t, err, "Unknown transform: integral")
+}
+
+func TestTransform_Apply(t *testing.T) {
+	start := time.Unix(1359849600, 0)
+
+	tests := []struct {
+		name   string
+		kind   string
+		values []interface{}
+		want   []interface{}
+	}{
+		{
+			name:   "no transform",
+			kind:   "",
+			values: []interface{}{int64(10), "text"},
+			want:   []interface{}{int64(10), "text"},
+		},
+		{
+			name:   "delta of integers",
+			kind:   DeltaTransform,
+			values: []interface{}{int64(10), int64(25), int64(20)},
+			want:   []interface{}{nil, int64(15), int64(-5)},
+		},
+		{
+			name:   "delta of floats",
+			kind:   DeltaTransform,
+			values: []interface{}{1.5, 2.0},
+			want:   []interface{}{nil, 0.5},
+		},
+		{
+			name:   "rate",
+			kind:   RateTransform,
+			values: []interface{}{int64(100), int64(150), int64(50)},
+			want:   []interface{}{nil, 10.0, -20.0},
+		},
+		{
+			name:   "derivative with counter reset",
+			kind:   DerivativeTransform,
+			values: []interface{}{int64(100), int64(150), int64(20)},
+			want:   []interface{}{nil, 10.0, 4.0},
+		},
+		{
+			name:   "non numeric value",
+			kind:   RateTransform,
+			values: []interface{}{int64(100), "text", int64(200)},
+			want:   []interface{}{nil, nil, 10.0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr, err := NewTransform(tt.kind)
+			assert.NoError(t, err)
+
+			got := []interface{}{}
+			for i, v := range tt.values {
+				got = append(got, tr.Apply("service1", v, start.Add(time.Duration(i)*5*time.Second)))
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTransform_Apply_PerService(t *testing.T) {
+	tr, _ := NewTransform(DeltaTransform)
+	now := time.Unix(1359849600, 0)
+
+	assert.Nil(t, tr.Apply("service1", int64(10), now))
+	assert.Nil(t, tr.Apply("service2", int64(100), now))
+	assert.Equal(t, int64(5), tr.Apply("service1", int64(15), now.Add(time.Second)))
+	assert.Equal(t, int64(50), tr.Apply("service2", int64(150), now.Add(time.Second)))
+}
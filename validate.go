@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// RowWidth is the number of units that fit in a row.
+const RowWidth = 12
+
+// Problem is an error in the configuration, located by its file and the
+// JSON path within the file.
+type Problem struct {
+	File    string
+	Path    string
+	Message string
+}
+
+func (p *Problem) String() string {
+	if p.Path == "" {
+		return fmt.Sprintf("%s: %s", p.File, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.File, p.Path, p.Message)
+}
+
+type validator struct {
+	file       string
+	problems   []*Problem
+	services   map[string]string
+	dashboards map[string]string
+}
+
+func (v *validator) add(path string, format string, args ...interface{}) {
+	v.problems = append(v.problems, &Problem{
+		File:    v.file,
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// location describes where something is defined, for messages about
+// duplicates.
+func (v *validator) location(path string) string {
+	return fmt.Sprintf("%s: %s", filepath.Base(v.file), path)
+}
+
+// ValidateConf checks the configuration file, or all configuration files of
+// the directory, and reports every problem it finds. Unlike LoadConf it
+// doesn't stop at the first problem, and it also reports problems LoadConf
+// accepts, such as references to unknown services.
+func ValidateConf(path string) ([]*Problem, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	v := &validator{
+		services:   map[string]string{},
+		dashboards: map[string]string{},
+	}
+
+	raws := make([]*RawConfig, len(files))
+	for i, file := range files {
+		v.file = file
+		raws[i] = v.read(file)
+		if raws[i] == nil {
+			continue
+		}
+
+		for s, raw := range raws[i].Services {
+			v.checkService(fmt.Sprintf("services[%d]", s), raw)
+		}
+	}
+
+	for i, file := range files {
+		raw := raws[i]
+		if raw == nil {
+			continue
+		}
+		v.file = file
+
+		if len(raw.Rows) > 0 {
+			name := DefaultDashboard
+			if info.IsDir() {
+				name = strings.TrimSuffix(filepath.Base(file), ".json")
+			}
+			v.checkDashboard("rows", name, raw.Rows)
+		}
+		for d, dashboard := range raw.Dashboards {
+			path := fmt.Sprintf("dashboards[%d]", d)
+			v.checkDashboard(path+".rows", dashboard.Name, dashboard.Rows)
+		}
+
+		for a, alert := range raw.Alerts {
+			v.checkAlert(fmt.Sprintf("alerts[%d]", a), alert)
+		}
+		for w, webhook := range raw.Webhooks {
+			u, err := url.Parse(webhook)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+				v.add(fmt.Sprintf("webhooks[%d]", w), "Invalid webhook: %s", webhook)
+			}
+		}
+	}
+
+	return v.problems, nil
+}
+
+// read parses the file, reporting where its JSON is broken.
+func (v *validator) read(file string) *RawConfig {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		v.add("", "%s", err)
+		return nil
+	}
+
+	var raw RawConfig
+	err = json.Unmarshal(data, &raw)
+	if err == nil {
+		return &raw
+	}
+
+	switch e := err.(type) {
+	case *json.SyntaxError:
+		// the offset is just past the offending character
+		line, col := position(data, e.Offset-1)
+		v.add("", "Invalid JSON at line %d, column %d: %s", line, col, e)
+	case *json.UnmarshalTypeError:
+		line, col := position(data, e.Offset)
+		v.add("", "Invalid value at line %d, column %d: %s", line, col, e)
+	default:
+		v.add("", "%s", err)
+	}
+	return nil
+}
+
+// position converts an offset in the data to a line and a column.
+func position(data []byte, offset int64) (int, int) {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndex(before, []byte("\n"))
+	return line, col
+}
+
+func (v *validator) checkService(path string, raw RawService) {
+	if raw.Name == "" {
+		v.add(path+".name", "Missing name")
+	} else if first, ok := v.services[raw.Name]; ok {
+		v.add(path+".name", "Duplicate service: %s (first defined at %s)", raw.Name, first)
+	} else {
+		v.services[raw.Name] = v.location(path)
+	}
+
+	if raw.URL == "" {
+		v.add(path+".url", "Missing url")
+		return
+	}
+	if _, err := ReadService(raw); err != nil {
+		v.add(path, "%s", err)
+	}
+}
+
+func (v *validator) checkDashboard(path string, name string, rows []RawRow) {
+	if !dashboardName.MatchString(name) {
+		v.add(path, "Invalid dashboard name: %q", name)
+	} else if name == ScratchDashboard {
+		v.add(path, "Reserved dashboard name: %s holds the pinned widgets", name)
+	} else if first, ok := v.dashboards[name]; ok {
+		v.add(path, "Duplicate dashboard: %s (first defined at %s)", name, first)
+	} else {
+		v.dashboards[name] = v.location(path)
+	}
+
+	for r, row := range rows {
+		rowPath := fmt.Sprintf("%s[%d]", path, r)
+
+		width := 0
+		for i, item := range row.Items {
+			itemPath := fmt.Sprintf("%s.items[%d]", rowPath, i)
+			if item.Size < 1 || item.Size > RowWidth {
+				v.add(itemPath+".size", "Invalid size: %d, has to be between 1 and %d", item.Size, RowWidth)
+			}
+			width += item.Size
+			v.checkItem(itemPath, item)
+		}
+		if width > RowWidth {
+			v.add(rowPath, "Row is %d units wide, at most %d fit", width, RowWidth)
+		}
+	}
+}
+
+// widgetConf holds the settings shared by several widget types.
+type widgetConf struct {
+	Metric    *string         `json:"metric"`
+	Transform string          `json:"transform"`
+	Aggregate string          `json:"aggregate"`
+	Service   string          `json:"service"`
+	Services  []string        `json:"services"`
+	Max       json.RawMessage `json:"max"`
+}
+
+func (v *validator) checkItem(path string, item RawItem) {
+	if item.Conf == nil {
+		v.add(path+".conf", "Missing configuration")
+		return
+	}
+
+	var conf widgetConf
+	if err := json.Unmarshal(*item.Conf, &conf); err != nil {
+		v.add(path+".conf", "%s", err)
+		return
+	}
+
+	confPath := path + ".conf"
+	found := len(v.problems)
+
+	if conf.Metric != nil {
+		if _, err := NewMetric(*conf.Metric); err != nil {
+			v.add(confPath+".metric", "%s", err)
+		}
+	}
+	if _, err := NewTransform(conf.Transform); err != nil {
+		v.add(confPath+".transform", "%s", err)
+	}
+	if _, err := NewAggregate(conf.Aggregate); err != nil {
+		v.add(confPath+".aggregate", "%s", err)
+	}
+	if conf.Service != "" {
+		v.checkReference(confPath+".service", conf.Service)
+	}
+	for s, service := range conf.Services {
+		v.checkReference(fmt.Sprintf("%s.services[%d]", confPath, s), service)
+	}
+
+	switch item.Type {
+	case GaugeType, LineChartType, TextType, BarChartType, HistogramType, StatType:
+		if conf.Metric == nil {
+			v.add(confPath+".metric", "Missing metric")
+		}
+	}
+	switch item.Type {
+	case GaugeType, TextType, StatType:
+		if conf.Service == "" && conf.Aggregate == "" {
+			v.add(confPath+".service", "Missing service")
+		}
+	}
+	if item.Type == GaugeType {
+		var max int64
+		if len(conf.Max) == 0 {
+			v.add(confPath+".max", "Missing max")
+		} else if err := json.Unmarshal(conf.Max, &max); err != nil || max <= 0 {
+			v.add(confPath+".max", "Invalid max: %s", conf.Max)
+		}
+	}
+
+	if len(v.problems) > found {
+		return
+	}
+
+	// anything the checks above don't cover, like unknown types or missing
+	// metrics, is reported as the widget itself would report it
+	if _, err := ReadChart(item); err != nil {
+		if strings.HasPrefix(err.Error(), "Unknown widget type") {
+			v.add(path+".type", "%s", err)
+		} else {
+			v.add(confPath, "%s", err)
+		}
+	}
+}
+
+func (v *validator) checkReference(path string, service string) {
+	if _, ok := v.services[service]; !ok {
+		v.add(path, "Unknown service: %s", service)
+	}
+}
+
+func (v *validator) checkAlert(path string, raw RawAlert) {
+	services := []*Service{}
+	for name := range v.services {
+		services = append(services, &Service{Name: name})
+	}
+
+	if _, err := ReadAlert(raw, services); err != nil {
+		v.add(path, "%s", err)
+	}
+}
+
+// Validate runs the validate command and returns its exit code.
+func Validate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %s validate <dashboard.json or directory>\n", os.Args[0])
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	path := flags.Arg(0)
+
+	problems, err := ValidateConf(path)
+	if err != nil {
+		fmt.Println("Could not read dashboard configuration:", err)
+		return 1
+	}
+
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if len(problems) > 0 {
+		fmt.Printf("Found %d problem(s)\n", len(problems))
+		return 1
+	}
+
+	fmt.Printf("%s is valid\n", path)
+	return 0
+}
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// validateFiles writes the files to a temporary directory, validates it and
+// returns the problems as "file: path: message".
+func validateFiles(t *testing.T, files map[string]string) []string {
+	dir, err := ioutil.TempDir("", "validate")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	for name, data := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0644))
+	}
+
+	path := dir
+	if len(files) == 1 {
+		for name := range files {
+			path = filepath.Join(dir, name)
+		}
+	}
+
+	problems, err := ValidateConf(path)
+	assert.NoError(t, err)
+
+	result := []string{}
+	for _, p := range problems {
+		p.File = filepath.Base(p.File)
+		result = append(result, p.String())
+	}
+	return result
+}
+
+func TestValidateConf(t *testing.T) {
+	tests := []struct {
+		data     string
+		problems []string
+	}{
+		{
+			data: `{
+				"services": [{"name": "service1", "url": "localhost:4004"}],
+				"rows": [{"items": [
+					{"type": "LineChart", "size": 6, "conf": {"metric": "memstats.Alloc"}},
+					{"type": "Gauge", "size": 6, "conf": {"service": "service1", "metric": "a", "max": 10}}
+				]}]
+			}`,
+			problems: []string{},
+		},
+		{
+			data: `{
+				"services": [
+					{"name": "service1", "url": "localhost:4004"},
+					{"name": "service1", "url": "localhost:4005"},
+					{"url": "localhost:4006"},
+					{"name": "service4"},
+					{"name": "service5", "url": "localhost:4007", "format": "xml"}
+				]
+			}`,
+			problems: []string{
+				"dashboard.json: services[1].name: Duplicate service: service1 (first defined at dashboard.json: services[0])",
+				"dashboard.json: services[2].name: Missing name",
+				"dashboard.json: services[3].url: Missing url",
+				"dashboard.json: services[4]: Unknown format of service service5: xml",
+			},
+		},
+		{
+			data: `{
+				"services": [{"name": "service1", "url": "localhost:4004"}],
+				"rows": [
+					{"items": [{"type": "Text", "size": 12, "conf": {"service": "service1", "metric": "a"}}]},
+					{"items": [
+						{"type": "Gauge", "size": 6, "conf": {"service": "service-1", "metric": "a"}},
+						{"type": "LineChart", "size": 8, "conf": {"metric": "a", "services": ["service1", "service2"], "transform": "log"}},
+						{"type": "Pie", "size": 0, "conf": {}}
+					]}
+				]
+			}`,
+			problems: []string{
+				"dashboard.json: rows[1].items[0].conf.service: Unknown service: service-1",
+				"dashboard.json: rows[1].items[0].conf.max: Missing max",
+				"dashboard.json: rows[1].items[1].conf.transform: Unknown transform: log",
+				"dashboard.json: rows[1].items[1].conf.services[1]: Unknown service: service2",
+				"dashboard.json: rows[1].items[2].size: Invalid size: 0, has to be between 1 and 12",
+				"dashboard.json: rows[1].items[2].type: Unknown widget type: Pie",
+				"dashboard.json: rows[1]: Row is 14 units wide, at most 12 fit",
+			},
+		},
+		{
+			data: `{
+				"services": [{"name": "service1", "url": "localhost:4004"}],
+				"dashboards": [
+					{"name": "team a", "rows": [{"items": [{"type": "Gauge", "size": 4, "conf": {"metric": "a", "max": -1}}]}]},
+					{"name": "team-b", "rows": [{"items": [{"type": "LineChart", "size": 4}, {"type": "Text", "size": 4, "conf": {"service": "service1"}}]}]}
+				],
+				"alerts": [{"name": "slow", "service": "service2", "metric": "a", "comparison": ">"}],
+				"webhooks": ["hooks.example.com"]
+			}`,
+			problems: []string{
+				"dashboard.json: dashboards[0].rows: Invalid dashboard name: \"team a\"",
+				"dashboard.json: dashboards[0].rows[0].items[0].conf.service: Missing service",
+				"dashboard.json: dashboards[0].rows[0].items[0].conf.max: Invalid max: -1",
+				"dashboard.json: dashboards[1].rows[0].items[0].conf: Missing configuration",
+				"dashboard.json: dashboards[1].rows[0].items[1].conf.metric: Missing metric",
+				"dashboard.json: alerts[0]: Unknown service of alert slow: service2",
+				"dashboard.json: webhooks[0]: Invalid webhook: hooks.example.com",
+			},
+		},
+		{
+			data: `{
+				"services": [{"name": "service1", "url": "localhost:4004"}],
+				"dashboards": [{"name": "scratch", "rows": []}]
+			}`,
+			problems: []string{
+				"dashboard.json: dashboards[0].rows: Reserved dashboard name: scratch holds the pinned widgets",
+			},
+		},
+		{
+			data: `{
+				"services": [
+					{"name": "service1", "url": "localhost:4004"}
+				],,
+			}`,
+			problems: []string{
+				"dashboard.json: Invalid JSON at line 4, column 7: invalid character ',' looking for beginning of object key string",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		problems := validateFiles(t, map[string]string{"dashboard.json": test.data})
+		assert.Equal(t, test.problems, problems)
+	}
+}
+
+func TestValidateConf_Directory(t *testing.T) {
+	problems := validateFiles(t, map[string]string{
+		"services.json": `{"services": [{"name": "service1", "url": "localhost:4004"}]}`,
+		"memory.json":   `{"rows": [{"items": [{"type": "Text", "size": 4, "conf": {"service": "service1", "metric": "a"}}]}]}`,
+		"other.json": `{
+			"services": [{"name": "service1", "url": "localhost:4005"}],
+			"dashboards": [{"name": "memory", "rows": []}]
+		}`,
+	})
+
+	assert.Equal(t, []string{
+		"services.json: services[0].name: Duplicate service: service1 (first defined at other.json: services[0])",
+		"other.json: dashboards[0].rows: Duplicate dashboard: memory (first defined at memory.json: rows)",
+	}, problems)
+}
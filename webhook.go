@@ -0,0 +1,53 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+type Notifier interface {
+	Notify(e *AlertEvent)
+}
+
+// Webhooks posts the alert events as JSON to every configured URL.
+type Webhooks struct {
+	client *http.Client
+	urls   []string
+}
+
+func NewWebhooks(urls []string) *Webhooks {
+	return &Webhooks{
+		client: &http.Client{
+			Timeout: 5 * time.Second,
+		},
+		urls: urls,
+	}
+}
+
+func (w *Webhooks) Notify(e *AlertEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		fmt.Println("Error serializing alert:", err)
+		return
+	}
+
+	for _, url := range w.urls {
+		go w.post(url, data)
+	}
+}
+
+func (w *Webhooks) post(url string, data []byte) {
+	resp, err := w.client.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		fmt.Printf("Failed to notify '%s': %s\n", url, err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		fmt.Printf("Failed to notify '%s': %s\n", url, resp.Status)
+	}
+}
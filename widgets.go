@@ -2,14 +2,25 @@ package main
 
 import (
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/antonholmquist/jason"
 )
 
 const (
 	GaugeType     = "Gauge"
 	LineChartType = "LineChart"
 	TextType      = "Text"
+	StatusType    = "Status"
+	GCPausesType  = "GCPauses"
+	TableType     = "Table"
+	BarChartType  = "BarChart"
+	HistogramType = "Histogram"
+	StatType      = "Stat"
 )
 
 type Widget interface {
@@ -17,15 +28,25 @@ type Widget interface {
 	SetID(string)
 	Title() string
 	HasLegend() bool
-	Series() []string
+	Series(all []string) []string
+	Sources(all []string) []string
 }
 
 type LineChart struct {
-	cid        string   `json:"-"`
-	Metric     *Metric  `json:"-"`
-	MetricName string   `json:"metric"`
-	ShowLegend *bool    `json:"show_legend"`
-	Services   []string `json:"services"`
+	cid           string     `json:"-"`
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Aggregate     *Aggregate `json:"-"`
+	AggregateName string     `json:"aggregate"`
+	ShowServices  bool       `json:"show_services"`
+	ShowLegend    *bool      `json:"show_legend"`
+	Services      []string   `json:"services"`
+
+	// the series of a wildcard metric, in the order they were found
+	mu     sync.Mutex
+	labels []string
 }
 
 func (c *LineChart) ID() string {
@@ -37,7 +58,7 @@ func (c *LineChart) SetID(id string) {
 }
 
 func (c *LineChart) Title() string {
-	return c.Metric.String()
+	return MetricTitle(c.Metric, c.Transform)
 }
 
 func (c *LineChart) HasLegend() bool {
@@ -47,16 +68,76 @@ func (c *LineChart) HasLegend() bool {
 	return *c.ShowLegend
 }
 
-func (c *LineChart) Series() []string {
-	return c.Services
+func (c *LineChart) Series(all []string) []string {
+	if c.Metric.Wildcard() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return append([]string{}, c.labels...)
+	}
+
+	services := c.Services
+	if len(services) == 0 {
+		services = all
+	}
+	if c.Aggregate == nil {
+		return services
+	}
+
+	series := []string{}
+	if c.ShowServices {
+		series = append(series, services...)
+	}
+	return append(series, c.Aggregate.String())
+}
+
+// Key identifies the series of the chart independently of its position in
+// the configuration. The series of a wildcard metric change as variables
+// appear, so its key is made of the services and the aggregate instead.
+func (c *LineChart) Key(all []string) string {
+	if c.Metric.Wildcard() {
+		key := MetricTitle(c.Metric, c.Transform) + "|" + strings.Join(c.Sources(all), ",") + "|*"
+		if c.Aggregate != nil {
+			key += c.Aggregate.String()
+		}
+		return key
+	}
+	return MetricTitle(c.Metric, c.Transform) + "|" + strings.Join(c.Series(all), ",")
+}
+
+// Observe adds the series of a wildcard metric that weren't seen before, and
+// returns all series.
+func (c *LineChart) Observe(labels []string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	known := map[string]bool{}
+	for _, l := range c.labels {
+		known[l] = true
+	}
+	for _, l := range labels {
+		if !known[l] {
+			c.labels = append(c.labels, l)
+			known[l] = true
+		}
+	}
+	return append([]string{}, c.labels...)
+}
+
+func (c *LineChart) Sources(all []string) []string {
+	return listed(c.Services, all)
 }
 
 type Gauge struct {
-	cid        string  `json:"-"`
-	Metric     *Metric `json:"-"`
-	MetricName string  `json:"metric"`
-	Service    string  `json:"service"`
-	MaxValue   int64   `json:"max"`
+	cid           string     `json:"-"`
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Aggregate     *Aggregate `json:"-"`
+	AggregateName string     `json:"aggregate"`
+	Service       string     `json:"service"`
+	Services      []string   `json:"services"`
+	MaxValue      int64      `json:"max"`
 }
 
 func (g *Gauge) ID() string {
@@ -68,22 +149,31 @@ func (g *Gauge) SetID(id string) {
 }
 
 func (g *Gauge) Title() string {
-	return g.Metric.String()
+	return MetricTitle(g.Metric, g.Transform)
 }
 
 func (g *Gauge) HasLegend() bool {
 	return false
 }
 
-func (g *Gauge) Series() []string {
+func (g *Gauge) Series(all []string) []string {
 	return []string{}
 }
 
+func (g *Gauge) Sources(all []string) []string {
+	return sources(g.Aggregate, g.Service, g.Services, all)
+}
+
 type Text struct {
-	cid        string  `json:"-"`
-	Metric     *Metric `json:"-"`
-	MetricName string  `json:"metric"`
-	Service    string  `json:"service"`
+	cid           string     `json:"-"`
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Aggregate     *Aggregate `json:"-"`
+	AggregateName string     `json:"aggregate"`
+	Service       string     `json:"service"`
+	Services      []string   `json:"services"`
 }
 
 func (t *Text) ID() string {
@@ -95,22 +185,420 @@ func (t *Text) SetID(id string) {
 }
 
 func (t *Text) Title() string {
-	return t.Metric.String()
+	return MetricTitle(t.Metric, t.Transform)
 }
 
 func (t *Text) HasLegend() bool {
 	return false
 }
 
-func (t *Text) Series() []string {
+func (t *Text) Series(all []string) []string {
+	return []string{}
+}
+
+func (t *Text) Sources(all []string) []string {
+	return sources(t.Aggregate, t.Service, t.Services, all)
+}
+
+// Stat shows the current value of a metric as a formatted number, colored by
+// the thresholds it reached, with a sparkline of its recent values.
+type Stat struct {
+	cid           string     `json:"-"`
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Aggregate     *Aggregate `json:"-"`
+	AggregateName string     `json:"aggregate"`
+	Service       string     `json:"service"`
+	Services      []string   `json:"services"`
+	Format        string     `json:"format"`
+	Decimals      *int       `json:"decimals"`
+	Prefix        string     `json:"prefix"`
+	Suffix        string     `json:"suffix"`
+	Thresholds
+}
+
+func (s *Stat) ID() string {
+	return s.cid
+}
+
+func (s *Stat) SetID(id string) {
+	s.cid = id
+}
+
+func (s *Stat) Title() string {
+	return MetricTitle(s.Metric, s.Transform)
+}
+
+func (s *Stat) HasLegend() bool {
+	return false
+}
+
+func (s *Stat) Series(all []string) []string {
+	return []string{}
+}
+
+func (s *Stat) Sources(all []string) []string {
+	return sources(s.Aggregate, s.Service, s.Services, all)
+}
+
+// Key identifies the values of the stat independently of its position in
+// the configuration, so that its sparkline survives reloads.
+func (s *Stat) Key(all []string) string {
+	key := MetricTitle(s.Metric, s.Transform) + "|" + strings.Join(s.Sources(all), ",") + "|"
+	if s.Aggregate != nil {
+		key += s.Aggregate.String()
+	}
+	return key
+}
+
+// Status lists the up/down state of the services.
+type Status struct {
+	cid      string   `json:"-"`
+	Services []string `json:"services"`
+}
+
+func (s *Status) ID() string {
+	return s.cid
+}
+
+func (s *Status) SetID(id string) {
+	s.cid = id
+}
+
+func (s *Status) Title() string {
+	return "Status"
+}
+
+func (s *Status) HasLegend() bool {
+	return false
+}
+
+func (s *Status) Series(all []string) []string {
+	return []string{}
+}
+
+func (s *Status) Sources(all []string) []string {
+	return listed(s.Services, all)
+}
+
+// GCPauses shows the durations of the garbage collection pauses of the
+// services as a heatmap over time.
+type GCPauses struct {
+	cid      string   `json:"-"`
+	Services []string `json:"services"`
+	MaxValue float64  `json:"max"`
+	Buckets  int      `json:"buckets"`
+
+	// the number of the last garbage collection seen, per service
+	last map[string]int64
+}
+
+func (g *GCPauses) ID() string {
+	return g.cid
+}
+
+func (g *GCPauses) SetID(id string) {
+	g.cid = id
+}
+
+func (g *GCPauses) Title() string {
+	return "GC pauses"
+}
+
+func (g *GCPauses) HasLegend() bool {
+	return false
+}
+
+func (g *GCPauses) Series(all []string) []string {
+	return []string{}
+}
+
+func (g *GCPauses) Sources(all []string) []string {
+	return listed(g.Services, all)
+}
+
+// Pauses returns the pauses of the garbage collections of the service since
+// the last crawl. The first crawl of a service only remembers where its
+// pauses end.
+func (g *GCPauses) Pauses(service string, vars *Expvars) []*GCPause {
+	last, seen := g.last[service]
+
+	pauses, numGC, ok := ReadPauses(vars, last)
+	if !ok {
+		return nil
+	}
+
+	if g.last == nil {
+		g.last = map[string]int64{}
+	}
+	g.last[service] = numGC
+
+	if !seen {
+		return nil
+	}
+	for _, p := range pauses {
+		p.Service = service
+	}
+	return pauses
+}
+
+// Orders of the bars of a BarChart.
+const (
+	AscendingOrder  = "asc"
+	DescendingOrder = "desc"
+)
+
+// BarChart compares the current value of a metric across the services, one
+// bar per service.
+type BarChart struct {
+	cid           string     `json:"-"`
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Services      []string   `json:"services"`
+	Sort          string     `json:"sort"`
+	Horizontal    bool       `json:"horizontal"`
+}
+
+func (b *BarChart) ID() string {
+	return b.cid
+}
+
+func (b *BarChart) SetID(id string) {
+	b.cid = id
+}
+
+func (b *BarChart) Title() string {
+	return MetricTitle(b.Metric, b.Transform)
+}
+
+func (b *BarChart) HasLegend() bool {
+	return false
+}
+
+func (b *BarChart) Series(all []string) []string {
+	return []string{}
+}
+
+func (b *BarChart) Sources(all []string) []string {
+	return listed(b.Services, all)
+}
+
+// Histogram shows the distribution of a metric published as a map of
+// buckets or as an array of samples, along with its percentiles over time.
+type Histogram struct {
+	cid         string    `json:"-"`
+	Metric      *Metric   `json:"-"`
+	MetricName  string    `json:"metric"`
+	Services    []string  `json:"services"`
+	Percentiles []float64 `json:"percentiles"`
+	Delta       bool      `json:"delta"`
+	Buckets     int       `json:"buckets"`
+
+	// the counts of the buckets at the last crawl, per service
+	last map[string]map[float64]float64
+}
+
+func (h *Histogram) ID() string {
+	return h.cid
+}
+
+func (h *Histogram) SetID(id string) {
+	h.cid = id
+}
+
+func (h *Histogram) Title() string {
+	return h.Metric.String()
+}
+
+func (h *Histogram) HasLegend() bool {
+	return true
+}
+
+func (h *Histogram) Series(all []string) []string {
+	series := []string{}
+	for _, p := range h.Percentiles {
+		series = append(series, fmt.Sprintf("p%g", p))
+	}
+	return series
+}
+
+func (h *Histogram) Sources(all []string) []string {
+	return listed(h.Services, all)
+}
+
+// Key identifies the percentiles of the histogram independently of its
+// position in the configuration, so that they survive reloads.
+func (h *Histogram) Key(all []string) string {
+	return fmt.Sprintf("%s|%s|%s|%t|%d", h.Metric, strings.Join(h.Sources(all), ","), strings.Join(h.Series(all), ","), h.Delta, h.Buckets)
+}
+
+// Distribution merges the histograms of the services. Buckets are summed by
+// their upper bounds, samples are split into buckets of equal width. With
+// Delta, only the counts added to the buckets since the last crawl are
+// used, so the first crawl of a service adds nothing.
+func (h *Histogram) Distribution(services []string, vars map[string]*Expvars) *Distribution {
+	counts := map[float64]float64{}
+	samples := []float64{}
+	buckets := false
+	for _, s := range services {
+		if vars[s] == nil {
+			continue
+		}
+		value, err := vars[s].GetValue(h.Metric.Path...)
+		if err != nil {
+			continue
+		}
+
+		if c, ok := readBuckets(value); ok {
+			buckets = true
+			if h.Delta {
+				last, seen := h.last[s]
+				if h.last == nil {
+					h.last = map[string]map[float64]float64{}
+				}
+				h.last[s] = c
+				if !seen {
+					continue
+				}
+				c = deltaBuckets(last, c)
+			}
+			for bound, count := range c {
+				counts[bound] += count
+			}
+		} else if v, ok := readSamples(value); ok {
+			samples = append(samples, v...)
+		}
+	}
+
+	lower := 0.0
+	if len(samples) > 0 {
+		lower = samples[0]
+		for _, v := range samples {
+			lower = math.Min(lower, v)
+		}
+		if buckets {
+			lower = math.Min(lower, 0)
+		}
+	}
+	binSamples(samples, h.Buckets, counts)
+
+	return NewDistribution(lower, counts)
+}
+
+// Table shows the current values of several metrics of every service, one
+// service per row.
+type Table struct {
+	cid      string         `json:"-"`
+	Columns  []*TableColumn `json:"columns"`
+	Services []string       `json:"services"`
+}
+
+// TableColumn is a metric of a Table.
+type TableColumn struct {
+	Metric        *Metric    `json:"-"`
+	MetricName    string     `json:"metric"`
+	Label         string     `json:"label"`
+	Format        string     `json:"format"`
+	Transform     *Transform `json:"-"`
+	TransformName string     `json:"transform"`
+	Thresholds
+}
+
+func (t *Table) ID() string {
+	return t.cid
+}
+
+func (t *Table) SetID(id string) {
+	t.cid = id
+}
+
+func (t *Table) Title() string {
+	return "Services"
+}
+
+func (t *Table) HasLegend() bool {
+	return false
+}
+
+func (t *Table) Series(all []string) []string {
 	return []string{}
 }
 
+func (t *Table) Sources(all []string) []string {
+	return listed(t.Services, all)
+}
+
+// Labels returns the headers of the columns.
+func (t *Table) Labels() []string {
+	labels := []string{}
+	for _, c := range t.Columns {
+		labels = append(labels, c.Label)
+	}
+	return labels
+}
+
+// Thresholds highlight the values that reach them. When the critical
+// threshold is below the warning one, lower values are worse.
+type Thresholds struct {
+	Warning  *float64 `json:"warning"`
+	Critical *float64 `json:"critical"`
+}
+
+// Level tells whether the value reached the critical or the warning
+// threshold.
+func (t Thresholds) Level(v float64) string {
+	reached := func(threshold *float64) bool {
+		if threshold == nil {
+			return false
+		}
+		if t.Warning != nil && t.Critical != nil && *t.Critical < *t.Warning {
+			return v <= *threshold
+		}
+		return v >= *threshold
+	}
+
+	if reached(t.Critical) {
+		return "critical"
+	}
+	if reached(t.Warning) {
+		return "warning"
+	}
+	return ""
+}
+
+// sources returns the services read by a widget that shows either the metric
+// of a single service or its aggregate across several services.
+func sources(a *Aggregate, service string, services []string, all []string) []string {
+	if a == nil {
+		return []string{service}
+	}
+	return listed(services, all)
+}
+
+// listed returns the services listed by a widget, or all of them if it lists
+// none.
+func listed(services []string, all []string) []string {
+	if len(services) == 0 {
+		return all
+	}
+	return services
+}
+
 type Widgets struct {
 	nextID     int
 	Gauges     []*Gauge
 	LineCharts []*LineChart
 	Texts      []*Text
+	Statuses   []*Status
+	GCPauses   []*GCPauses
+	Tables     []*Table
+	BarCharts  []*BarChart
+	Histograms []*Histogram
+	Stats      []*Stat
 }
 
 func (ww *Widgets) NextID() string {
@@ -129,21 +617,211 @@ func (ww *Widgets) Append(widget Widget) error {
 	case *Text:
 		ww.Texts = append(ww.Texts, c)
 		return nil
+	case *Status:
+		ww.Statuses = append(ww.Statuses, c)
+		return nil
+	case *GCPauses:
+		ww.GCPauses = append(ww.GCPauses, c)
+		return nil
+	case *Table:
+		ww.Tables = append(ww.Tables, c)
+		return nil
+	case *BarChart:
+		ww.BarCharts = append(ww.BarCharts, c)
+		return nil
+	case *Histogram:
+		ww.Histograms = append(ww.Histograms, c)
+		return nil
+	case *Stat:
+		ww.Stats = append(ww.Stats, c)
+		return nil
 	default:
 		return fmt.Errorf("Unknown widget type: %s", reflect.TypeOf(widget))
 	}
 }
 
+// Keep replaces the widgets with the running ones of the same IDs and
+// types, so that the state of the running widgets, like the samples of
+// their transforms, survives adding others.
+func (ww *Widgets) Keep(running *Widgets) *Widgets {
+	old := map[string]Widget{}
+	for _, w := range running.All() {
+		old[w.ID()] = w
+	}
+
+	kept := &Widgets{nextID: ww.nextID}
+	for _, w := range ww.All() {
+		if o, ok := old[w.ID()]; ok && reflect.TypeOf(o) == reflect.TypeOf(w) {
+			w = o
+		}
+		kept.Append(w)
+	}
+	return kept
+}
+
+func (ww *Widgets) All() []Widget {
+	widgets := []Widget{}
+	for _, g := range ww.Gauges {
+		widgets = append(widgets, g)
+	}
+	for _, c := range ww.LineCharts {
+		widgets = append(widgets, c)
+	}
+	for _, t := range ww.Texts {
+		widgets = append(widgets, t)
+	}
+	for _, s := range ww.Statuses {
+		widgets = append(widgets, s)
+	}
+	for _, g := range ww.GCPauses {
+		widgets = append(widgets, g)
+	}
+	for _, t := range ww.Tables {
+		widgets = append(widgets, t)
+	}
+	for _, b := range ww.BarCharts {
+		widgets = append(widgets, b)
+	}
+	for _, h := range ww.Histograms {
+		widgets = append(widgets, h)
+	}
+	for _, s := range ww.Stats {
+		widgets = append(widgets, s)
+	}
+	return widgets
+}
+
+// Sources returns the services that have to be crawled for the widgets.
+func (ww *Widgets) Sources(all []string) map[string]struct{} {
+	used := map[string]struct{}{}
+
+	for _, w := range ww.All() {
+		for _, s := range w.Sources(all) {
+			used[s] = struct{}{}
+		}
+	}
+	return used
+}
+
+// Metric is either a path to a variable or an expression computed from
+// several variables.
 type Metric struct {
 	Path []string
+	expr expr
+	name string
 }
 
+// NewMetric reads the name as a path, unless it has spaces, parentheses or
+// quotes, which only expressions have. Keys may contain a minus, so
+// "http-requests" is a path and "a - b" is an expression, but other operators
+// within a path, like in "a*100", are most likely a missing space.
 func NewMetric(name string) (*Metric, error) {
+	if name != "" && !strings.ContainsAny(name, "()'") && strings.IndexFunc(name, unicode.IsSpace) < 0 {
+		if i := pathOperator(name); i >= 0 {
+			return nil, fmt.Errorf("Invalid metric %q: ambiguous %q in path at position %d, quote the path or put spaces around the operator", name, []rune(name)[i], i)
+		}
+		return &Metric{
+			Path: strings.Split(name, "."),
+		}, nil
+	}
+
+	e, err := ParseExpr(name)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid metric %q: %s", name, err)
+	}
+	if p, ok := e.(*pathExpr); ok {
+		return &Metric{
+			Path: p.path,
+		}, nil
+	}
+
 	return &Metric{
-		Path: strings.Split(name, "."),
+		expr: e,
+		name: strings.TrimSpace(name),
 	}, nil
 }
 
+// pathOperator returns the position of the first '*', '+' or '/' within the
+// keys of the path, or -1. A key that is just '*' is a wildcard.
+func pathOperator(path string) int {
+	runes := []rune(path)
+	start := 0
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && runes[i] != '.' {
+			continue
+		}
+		if key := runes[start:i]; string(key) != "*" {
+			for j, r := range key {
+				if strings.ContainsRune("*+/", r) {
+					return start + j
+				}
+			}
+		}
+		start = i + 1
+	}
+	return -1
+}
+
+// Wildcard tells whether the path of the metric has wildcards, matching any
+// key.
+func (m *Metric) Wildcard() bool {
+	for _, key := range m.Path {
+		if key == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// Expand finds the variables matched by the wildcards of the path. The
+// metrics of the variables are keyed by the keys the wildcards matched,
+// joined by dots.
+func (m *Metric) Expand(vars *Expvars) map[string]*Metric {
+	matches := map[string]*Metric{}
+	if vars != nil {
+		expand(vars.Object, m.Path, nil, nil, matches)
+	}
+	return matches
+}
+
+func expand(o *jason.Object, path, matched, resolved []string, matches map[string]*Metric) {
+	key, rest := path[0], path[1:]
+
+	values := map[string]*jason.Value{}
+	if key == "*" {
+		values = o.Map()
+	} else if v, err := o.GetValue(key); err == nil {
+		values[key] = v
+	}
+
+	for k, v := range values {
+		m := matched
+		if key == "*" {
+			m = append(matched[:len(matched):len(matched)], k)
+		}
+		r := append(resolved[:len(resolved):len(resolved)], k)
+
+		if len(rest) == 0 {
+			matches[strings.Join(m, ".")] = &Metric{Path: r}
+			continue
+		}
+		if obj, err := v.Object(); err == nil {
+			expand(obj, rest, m, r, matches)
+		}
+	}
+}
+
 func (m *Metric) String() string {
+	if m.expr != nil {
+		return m.name
+	}
 	return strings.Join(m.Path, ".")
 }
+
+// MetricTitle is the default title of a widget showing the metric.
+func MetricTitle(m *Metric, t *Transform) string {
+	if t == nil {
+		return m.String()
+	}
+	return fmt.Sprintf("%s(%s)", t, m)
+}